@@ -0,0 +1,127 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package accel
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidFormat is returned if a file is not a valid accelerometer capture
+var ErrInvalidFormat = errors.New("Invalid accelerometer capture")
+
+// Capture holds the samples of an accelerometer run
+type Capture struct {
+	// Axes are the names of the captured axes, e.g. X, Y and Z
+	Axes []string
+	// Samples holds the acceleration values (in g) of each axis
+	Samples [][]float64
+	// Rate is the sampling rate (in Hz)
+	Rate float64
+	// Overflows is the number of samples that were lost
+	Overflows int
+}
+
+// PathResolver converts firmware paths into real paths, e.g. a connection.CommandConnection
+type PathResolver interface {
+	ResolvePath(path string) (string, error)
+}
+
+// LoadResolved loads a capture from a firmware path like 0:/sys/accelerometer/1.csv
+func LoadResolved(r PathResolver, file string) (*Capture, error) {
+	path, err := r.ResolvePath(file)
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads a capture file
+func Load(path string) (*Capture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a capture in the format written by M956. It starts with a line
+// like "Sample,X,Y,Z" followed by one line per sample and ends with a summary
+// like "Rate 1344 overflows 0".
+func Parse(r io.Reader) (*Capture, error) {
+	c := &Capture{}
+	s := bufio.NewScanner(r)
+	header := false
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		first := strings.ToLower(strings.TrimSpace(fields[0]))
+		switch {
+		case first == "sample":
+			for _, f := range fields[1:] {
+				c.Axes = append(c.Axes, strings.TrimSpace(f))
+			}
+			c.Samples = make([][]float64, len(c.Axes))
+			header = true
+		case strings.HasPrefix(first, "rate"):
+			c.parseSummary(line)
+		case header:
+			if len(fields) < len(c.Axes)+1 {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFormat, line)
+			}
+			for i := range c.Axes {
+				v, err := strconv.ParseFloat(strings.TrimSpace(fields[i+1]), 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, err)
+				}
+				c.Samples[i] = append(c.Samples[i], v)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, ErrInvalidFormat
+	}
+	return c, nil
+}
+
+// parseSummary reads the name/value pairs of the last line
+func (c *Capture) parseSummary(line string) {
+	words := strings.Fields(strings.Replace(line, ",", " ", -1))
+	for i := 0; i+1 < len(words); i++ {
+		switch strings.ToLower(words[i]) {
+		case "rate":
+			c.Rate, _ = strconv.ParseFloat(words[i+1], 64)
+		case "overflows":
+			c.Overflows, _ = strconv.Atoi(words[i+1])
+		}
+	}
+}
+
+// Axis returns the samples of the given axis or nil if it was not captured
+func (c *Capture) Axis(name string) []float64 {
+	for i, a := range c.Axes {
+		if strings.EqualFold(a, name) {
+			return c.Samples[i]
+		}
+	}
+	return nil
+}
+
+// Duration returns the length of the capture (in s)
+func (c *Capture) Duration() float64 {
+	if c.Rate <= 0 || len(c.Samples) == 0 {
+		return 0
+	}
+	return float64(len(c.Samples[0])) / c.Rate
+}
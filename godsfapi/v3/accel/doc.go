@@ -0,0 +1,11 @@
+/*
+Package accel analyses accelerometer captures for resonance tuning.
+
+M956 writes the collected accelerometer samples as CSV file to the SD card.
+This package parses such captures, calculates the power spectral density of
+every axis using Welch's method, detects resonance peaks and recommends the
+input shaping settings that reduce the measured vibration best. Spectra can
+be plotted as PNG images.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package accel
@@ -0,0 +1,135 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package accel
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Default values for PlotOptions
+const (
+	DefaultPlotWidth  = 800
+	DefaultPlotHeight = 400
+)
+
+// axisColors are the colors of the X, Y and Z axes followed by further spectra
+var axisColors = []color.RGBA{
+	{R: 0xd0, G: 0x20, B: 0x20, A: 0xff},
+	{R: 0x20, G: 0xa0, B: 0x20, A: 0xff},
+	{R: 0x20, G: 0x40, B: 0xd0, A: 0xff},
+	{R: 0x40, G: 0x40, B: 0x40, A: 0xff},
+}
+
+var (
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gridColor       = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+)
+
+// PlotOptions control how spectra are plotted
+type PlotOptions struct {
+	// Width of the image (in pixels)
+	Width int
+	// Height of the image (in pixels)
+	Height int
+	// MaxFrequency is the highest frequency shown (in Hz) or 0 for MaxShaperFrequency
+	MaxFrequency float64
+}
+
+// RenderPNG plots the given spectra with a linear frequency axis and grid lines
+// every 25 Hz. Spectra are drawn in red, green, blue and grey in this order.
+func RenderPNG(w io.Writer, spectra []*Spectrum, opts PlotOptions) error {
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = DefaultPlotWidth
+	}
+	if height <= 0 {
+		height = DefaultPlotHeight
+	}
+	maxFreq := opts.MaxFrequency
+	if maxFreq <= 0 {
+		maxFreq = MaxShaperFrequency
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, backgroundColor)
+		}
+	}
+	for f := 25.0; f < maxFreq; f += 25 {
+		x := int(f / maxFreq * float64(width-1))
+		for y := 0; y < height; y++ {
+			img.SetRGBA(x, y, gridColor)
+		}
+	}
+
+	var maxPower float64
+	for _, s := range spectra {
+		for k, f := range s.Frequencies {
+			if f <= maxFreq {
+				maxPower = math.Max(maxPower, s.Power[k])
+			}
+		}
+	}
+	if maxPower <= 0 {
+		return png.Encode(w, img)
+	}
+
+	for i, s := range spectra {
+		c := axisColors[len(axisColors)-1]
+		if i < len(axisColors) {
+			c = axisColors[i]
+		}
+		lastX, lastY := -1, 0
+		for k, f := range s.Frequencies {
+			if f > maxFreq {
+				break
+			}
+			x := int(f / maxFreq * float64(width-1))
+			y := height - 1 - int(s.Power[k]/maxPower*float64(height-1))
+			if lastX >= 0 {
+				line(img, lastX, lastY, x, y, c)
+			}
+			lastX, lastY = x, y
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// line draws a line using Bresenham's algorithm
+func line(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.SetRGBA(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,109 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package accel
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/shaping"
+)
+
+// Frequency range (in Hz) and step used to tune the shapers
+const (
+	MinShaperFrequency  = 5.0
+	MaxShaperFrequency  = 150.0
+	shaperFrequencyStep = 0.2
+)
+
+// testDampings are the damping ratios of the machine each shaper is evaluated
+// with because the real damping is rarely known exactly
+var testDampings = []float64{0.075, 0.1, 0.15}
+
+// Candidate is the best setting of a shaper type for a spectrum
+type Candidate struct {
+	// Shaper tuned to the spectrum
+	Shaper *shaping.Shaper `json:"shaper"`
+	// Vibration is the fraction of the measured vibration that remains
+	Vibration float64 `json:"vibration"`
+	// MaxAcceleration that keeps the smoothing acceptable (in mm/s^2)
+	MaxAcceleration float64 `json:"maxAcceleration"`
+}
+
+// Recommendation holds the suggested input shaping for a spectrum
+type Recommendation struct {
+	// Peak is the strongest resonance or nil if there is none
+	Peak *Peak `json:"peak"`
+	// Shaping is the recommended configuration
+	Shaping move.MoveInputShaping `json:"shaping"`
+	// Candidates are the best settings of every shaper type ordered by vibration
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Recommend tunes every shaper type to the given spectrum and recommends the one
+// that permits the highest acceleration within maxSmoothing (in mm) among those
+// whose remaining vibration is at most 20% above the lowest one
+func Recommend(s *Spectrum, maxSmoothing float64) (*Recommendation, error) {
+	var total float64
+	for k, f := range s.Frequencies {
+		if f >= MinShaperFrequency && f <= MaxShaperFrequency {
+			total += s.Power[k]
+		}
+	}
+	if total <= 0 {
+		return nil, errors.New("Spectrum contains no vibration")
+	}
+
+	r := &Recommendation{}
+	if peaks := s.Peaks(MinShaperFrequency, MaxShaperFrequency, 1); len(peaks) > 0 {
+		r.Peak = &peaks[0]
+	}
+	for _, t := range []move.MoveInputShapingType{move.ZVD, move.ZVDD, move.EI2} {
+		var best *Candidate
+		for f := MinShaperFrequency; f <= MaxShaperFrequency; f += shaperFrequencyStep {
+			sh, err := shaping.New(t, f, shaping.DefaultDamping)
+			if err != nil {
+				return nil, err
+			}
+			v := remainingVibration(s, sh, total)
+			// Prefer higher frequencies which smooth less
+			if best == nil || v <= best.Vibration {
+				best = &Candidate{Shaper: sh, Vibration: v, MaxAcceleration: sh.MaxAcceleration(maxSmoothing)}
+			}
+		}
+		r.Candidates = append(r.Candidates, *best)
+	}
+	sort.SliceStable(r.Candidates, func(i, j int) bool {
+		return r.Candidates[i].Vibration < r.Candidates[j].Vibration
+	})
+
+	choice := r.Candidates[0]
+	for _, c := range r.Candidates[1:] {
+		if c.Vibration <= r.Candidates[0].Vibration*1.2 && c.MaxAcceleration > choice.MaxAcceleration {
+			choice = c
+		}
+	}
+	r.Shaping = move.MoveInputShaping{
+		Type:      choice.Shaper.Type,
+		Frequency: math.Round(choice.Shaper.Frequency*10) / 10,
+		Damping:   choice.Shaper.Damping,
+	}
+	return r, nil
+}
+
+// remainingVibration weights the spectrum with the worst shaper response
+func remainingVibration(s *Spectrum, sh *shaping.Shaper, total float64) float64 {
+	var sum float64
+	for k, f := range s.Frequencies {
+		if f < MinShaperFrequency || f > MaxShaperFrequency {
+			continue
+		}
+		var worst float64
+		for _, d := range testDampings {
+			worst = math.Max(worst, sh.Vibration(f, d))
+		}
+		sum += s.Power[k] * worst
+	}
+	return sum / total
+}
@@ -0,0 +1,207 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package accel
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// DefaultSegmentSize is the default number of samples per FFT segment
+const DefaultSegmentSize = 512
+
+// Spectrum is the power spectral density of an axis
+type Spectrum struct {
+	// Axis name or "sum" for combined spectra
+	Axis string `json:"axis"`
+	// Frequencies of the bins (in Hz)
+	Frequencies []float64 `json:"frequencies"`
+	// Power spectral density of each bin (in g^2/Hz)
+	Power []float64 `json:"power"`
+}
+
+// Spectra calculates the spectrum of every captured axis
+func (c *Capture) Spectra(segmentSize int) ([]*Spectrum, error) {
+	result := make([]*Spectrum, len(c.Axes))
+	for i, a := range c.Axes {
+		s, err := PSD(c.Samples[i], c.Rate, segmentSize)
+		if err != nil {
+			return nil, err
+		}
+		s.Axis = a
+		result[i] = s
+	}
+	return result, nil
+}
+
+// PSD calculates the power spectral density using Welch's method with Hann
+// windows overlapping by half. The segment size is rounded down to a power of
+// two and reduced if there are fewer samples.
+func PSD(samples []float64, rate float64, segmentSize int) (*Spectrum, error) {
+	if rate <= 0 {
+		return nil, errors.New("Unknown sampling rate")
+	}
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	n := 1
+	for n*2 <= segmentSize && n*2 <= len(samples) {
+		n *= 2
+	}
+	if n < 8 {
+		return nil, errors.New("Too few samples")
+	}
+
+	window := make([]float64, n)
+	var u float64
+	for i := range window {
+		window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n))
+		u += window[i] * window[i]
+	}
+
+	power := make([]float64, n/2+1)
+	segments := 0
+	buf := make([]complex128, n)
+	for start := 0; start+n <= len(samples); start += n / 2 {
+		var mean float64
+		for _, v := range samples[start : start+n] {
+			mean += v
+		}
+		mean /= float64(n)
+		for i := range buf {
+			buf[i] = complex((samples[start+i]-mean)*window[i], 0)
+		}
+		fft(buf)
+		for k := range power {
+			a := cmplx.Abs(buf[k])
+			power[k] += a * a
+		}
+		segments++
+	}
+
+	s := &Spectrum{Frequencies: make([]float64, len(power)), Power: power}
+	for k := range power {
+		s.Frequencies[k] = float64(k) * rate / float64(n)
+		power[k] /= float64(segments) * rate * u
+		if k > 0 && k < n/2 {
+			// One-sided spectrum
+			power[k] *= 2
+		}
+	}
+	return s, nil
+}
+
+// Combine adds up spectra with the same frequency bins
+func Combine(spectra []*Spectrum) (*Spectrum, error) {
+	if len(spectra) == 0 {
+		return nil, errors.New("No spectra")
+	}
+	result := &Spectrum{
+		Axis:        "sum",
+		Frequencies: spectra[0].Frequencies,
+		Power:       make([]float64, len(spectra[0].Power)),
+	}
+	for _, s := range spectra {
+		if len(s.Power) != len(result.Power) {
+			return nil, errors.New("Spectra have different frequency bins")
+		}
+		for k, p := range s.Power {
+			result.Power[k] += p
+		}
+	}
+	return result, nil
+}
+
+// fft transforms x in place using the iterative radix-2 algorithm.
+// The length of x must be a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// Peak is a resonance found in a spectrum
+type Peak struct {
+	// Frequency of the peak (in Hz)
+	Frequency float64 `json:"frequency"`
+	// Power spectral density at the peak (in g^2/Hz)
+	Power float64 `json:"power"`
+	// Damping ratio estimated from the half-power bandwidth or 0 if unknown
+	Damping float64 `json:"damping"`
+}
+
+// Peaks returns up to count local maxima between min and max frequency (in Hz)
+// that reach at least a tenth of the highest one, ordered by power
+func (s *Spectrum) Peaks(min, max float64, count int) []Peak {
+	var highest float64
+	for k, f := range s.Frequencies {
+		if f >= min && f <= max {
+			highest = math.Max(highest, s.Power[k])
+		}
+	}
+
+	var peaks []Peak
+	for k := 1; k+1 < len(s.Power); k++ {
+		f, p := s.Frequencies[k], s.Power[k]
+		if f < min || f > max || p < highest/10 || p <= s.Power[k-1] || p < s.Power[k+1] {
+			continue
+		}
+		// Refine the frequency using a parabola through the neighbouring bins
+		df := s.Frequencies[1] - s.Frequencies[0]
+		a, b, c := s.Power[k-1], p, s.Power[k+1]
+		if d := a - 2*b + c; d != 0 {
+			f += df * 0.5 * (a - c) / d
+		}
+		peaks = append(peaks, Peak{Frequency: f, Power: p, Damping: s.damping(k)})
+	}
+
+	sort.Slice(peaks, func(i, j int) bool { return peaks[i].Power > peaks[j].Power })
+	if count > 0 && len(peaks) > count {
+		peaks = peaks[:count]
+	}
+	return peaks
+}
+
+// damping estimates the damping ratio of the peak at bin k as half the relative
+// width where the power is above half of the peak
+func (s *Spectrum) damping(k int) float64 {
+	half := s.Power[k] / 2
+	crossing := func(i, j int) float64 {
+		// Linear interpolation between bins i (below half) and j (above half)
+		t := (half - s.Power[i]) / (s.Power[j] - s.Power[i])
+		return s.Frequencies[i] + t*(s.Frequencies[j]-s.Frequencies[i])
+	}
+
+	lo := k
+	for lo > 0 && s.Power[lo] > half {
+		lo--
+	}
+	hi := k
+	for hi+1 < len(s.Power) && s.Power[hi] > half {
+		hi++
+	}
+	if s.Power[lo] > half || s.Power[hi] > half || s.Frequencies[k] <= 0 {
+		return 0
+	}
+	return (crossing(hi, hi-1) - crossing(lo, lo+1)) / (2 * s.Frequencies[k])
+}
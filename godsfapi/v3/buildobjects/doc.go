@@ -0,0 +1,11 @@
+/*
+Package buildobjects manages the build objects of the current job.
+
+RepRapFirmware tracks the objects of a job either by M486 labels or by the
+object comments of the slicer and reports them in job.Build. A Manager lists
+these objects, selects them by position and cancels or resumes them by name or
+index using M486. AddLabels adds M486 labels to G-code files that only carry
+slicer comments so that objects can be cancelled with any firmware version.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package buildobjects
@@ -0,0 +1,126 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package buildobjects
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLineLength is the longest G-code line that can be processed
+const maxLineLength = 1024 * 1024
+
+// markerKind is the meaning of a slicer comment
+type markerKind int
+
+const (
+	noMarker markerKind = iota
+	startMarker
+	endMarker
+)
+
+// LabelResult describes what AddLabels did
+type LabelResult struct {
+	// Objects are the names of the labelled objects by index
+	Objects []string `json:"objects"`
+	// AlreadyLabelled is true if the file contains M486 codes and was copied unchanged
+	AlreadyLabelled bool `json:"alreadyLabelled"`
+}
+
+// AddLabels copies G-code from r to w and adds M486 labels where the object
+// comments of PrusaSlicer, SuperSlicer, OrcaSlicer, Cura or ideaMaker start and
+// end an object. Files that contain M486 codes already are copied unchanged.
+func AddLabels(r io.ReadSeeker, w io.Writer) (*LabelResult, error) {
+	result := &LabelResult{}
+	labelled, err := hasLabels(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if labelled {
+		result.AlreadyLabelled = true
+		_, err = io.Copy(w, r)
+		return result, err
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineLength)
+	bw := bufio.NewWriter(w)
+	indices := make(map[string]int)
+	active := false
+	for s.Scan() {
+		line := s.Text()
+		kind, name := parseMarker(line)
+		if kind == endMarker && active {
+			bw.WriteString("M486 S-1\n")
+			active = false
+		}
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+		if kind == startMarker {
+			i, ok := indices[name]
+			if !ok {
+				i = len(result.Objects)
+				indices[name] = i
+				result.Objects = append(result.Objects, name)
+			}
+			fmt.Fprintf(bw, "M486 S%d A\"%s\"\n", i, strings.Replace(name, `"`, `""`, -1))
+			active = true
+		}
+	}
+	if err = s.Err(); err != nil {
+		return nil, err
+	}
+	if active {
+		bw.WriteString("M486 S-1\n")
+	}
+	return result, bw.Flush()
+}
+
+// hasLabels returns true if the G-code contains M486 codes
+func hasLabels(r io.Reader) (bool, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineLength)
+	for s.Scan() {
+		line := strings.ToUpper(strings.TrimSpace(s.Text()))
+		if strings.HasPrefix(line, "M486") && (len(line) == 4 || line[4] < '0' || line[4] > '9') {
+			return true, nil
+		}
+	}
+	return false, s.Err()
+}
+
+// parseMarker checks if a line is an object comment of a known slicer
+func parseMarker(line string) (markerKind, string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, ";") {
+		return noMarker, ""
+	}
+	c := strings.TrimSpace(line[1:])
+	switch {
+	case strings.HasPrefix(c, "printing object "):
+		// PrusaSlicer and SuperSlicer
+		return startMarker, strings.TrimSpace(c[len("printing object "):])
+	case strings.HasPrefix(c, "stop printing object"):
+		return endMarker, ""
+	case strings.HasPrefix(c, "start printing object, unique label id:"):
+		// OrcaSlicer
+		return startMarker, "object " + strings.TrimSpace(c[len("start printing object, unique label id:"):])
+	case strings.HasPrefix(c, "MESH:"):
+		// Cura
+		if name := strings.TrimSpace(c[len("MESH:"):]); name != "NONMESH" {
+			return startMarker, name
+		}
+		return endMarker, ""
+	case strings.HasPrefix(c, "PRINTING:"):
+		// ideaMaker
+		return startMarker, strings.TrimSpace(c[len("PRINTING:"):])
+	case strings.HasPrefix(c, "LAYER:"):
+		// Cura and ideaMaker only mark the start of objects on every layer
+		return endMarker, ""
+	}
+	return noMarker, ""
+}
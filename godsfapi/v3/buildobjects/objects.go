@@ -0,0 +1,189 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package buildobjects
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/job"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// Rect is an area in the XY plane
+type Rect struct {
+	// MinX is the lowest X coordinate (in mm)
+	MinX float64 `json:"minX"`
+	// MinY is the lowest Y coordinate (in mm)
+	MinY float64 `json:"minY"`
+	// MaxX is the highest X coordinate (in mm)
+	MaxX float64 `json:"maxX"`
+	// MaxY is the highest Y coordinate (in mm)
+	MaxY float64 `json:"maxY"`
+}
+
+// Contains returns true if the point is inside this rectangle
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.MinX && x <= r.MaxX && y >= r.MinY && y <= r.MaxY
+}
+
+// Intersects returns true if both rectangles overlap
+func (r Rect) Intersects(o Rect) bool {
+	return r.MinX <= o.MaxX && o.MinX <= r.MaxX && r.MinY <= o.MaxY && o.MinY <= r.MaxY
+}
+
+// Object is a build object of the current job
+type Object struct {
+	// Index of the object as used by M486
+	Index int `json:"index"`
+	// Name of the object or empty if unknown
+	Name string `json:"name"`
+	// Cancelled indicates if the object is cancelled
+	Cancelled bool `json:"cancelled"`
+	// Current indicates if the object is being printed
+	Current bool `json:"current"`
+	// Bounds of the object or nil if they are unknown
+	Bounds *Rect `json:"bounds"`
+}
+
+// Objects returns the build objects of the given build
+func Objects(b job.Build) []Object {
+	result := make([]Object, len(b.Objects))
+	for i, o := range b.Objects {
+		result[i] = Object{
+			Index:     i,
+			Name:      o.Name,
+			Cancelled: o.Cancelled,
+			Current:   int64(i) == b.CurrentObject,
+			Bounds:    bounds(o),
+		}
+	}
+	return result
+}
+
+// bounds returns the extents of an object if both coordinates are known
+func bounds(o job.BuildObject) *Rect {
+	if len(o.X) < 2 || len(o.Y) < 2 || o.X[0] == nil || o.X[1] == nil || o.Y[0] == nil || o.Y[1] == nil {
+		return nil
+	}
+	return &Rect{MinX: *o.X[0], MinY: *o.Y[0], MaxX: *o.X[1], MaxY: *o.Y[1]}
+}
+
+// Find returns the index of the object with the given name. Names are compared
+// case-insensitively if there is no exact match.
+func Find(b job.Build, name string) (int, error) {
+	for i, o := range b.Objects {
+		if o.Name == name {
+			return i, nil
+		}
+	}
+	for i, o := range b.Objects {
+		if strings.EqualFold(o.Name, name) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("Build object %q not found", name)
+}
+
+// At returns the objects whose bounds contain the given point
+func At(b job.Build, x, y float64) []Object {
+	var result []Object
+	for _, o := range Objects(b) {
+		if o.Bounds != nil && o.Bounds.Contains(x, y) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// Within returns the objects whose bounds intersect the given rectangle or,
+// if contained is true, lie completely inside of it
+func Within(b job.Build, r Rect, contained bool) []Object {
+	var result []Object
+	for _, o := range Objects(b) {
+		if o.Bounds == nil {
+			continue
+		}
+		if contained {
+			if r.Contains(o.Bounds.MinX, o.Bounds.MinY) && r.Contains(o.Bounds.MaxX, o.Bounds.MaxY) {
+				result = append(result, o)
+			}
+		} else if r.Intersects(*o.Bounds) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// Manager cancels and resumes build objects
+type Manager struct {
+	// Performer runs the M486 codes
+	Performer CodePerformer
+	// Channel the codes are sent to
+	Channel types.CodeChannel
+}
+
+// NewManager creates a new Manager sending codes to the default channel
+func NewManager(p CodePerformer) *Manager {
+	return &Manager{Performer: p, Channel: types.DefaultChannel}
+}
+
+func (m *Manager) perform(code string) error {
+	reply, err := m.Performer.PerformSimpleCode(code, m.Channel)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(reply, "Error") {
+		return fmt.Errorf("%s: %s", code, strings.TrimSpace(reply))
+	}
+	return nil
+}
+
+// Cancel cancels the object with the given index
+func (m *Manager) Cancel(index int) error {
+	return m.perform(fmt.Sprintf("M486 P%d", index))
+}
+
+// Resume resumes the cancelled object with the given index
+func (m *Manager) Resume(index int) error {
+	return m.perform(fmt.Sprintf("M486 U%d", index))
+}
+
+// CancelCurrent cancels the object that is being printed
+func (m *Manager) CancelCurrent() error {
+	return m.perform("M486 C")
+}
+
+// CancelByName cancels the object with the given name
+func (m *Manager) CancelByName(b job.Build, name string) error {
+	i, err := Find(b, name)
+	if err != nil {
+		return err
+	}
+	return m.Cancel(i)
+}
+
+// ResumeByName resumes the cancelled object with the given name
+func (m *Manager) ResumeByName(b job.Build, name string) error {
+	i, err := Find(b, name)
+	if err != nil {
+		return err
+	}
+	return m.Resume(i)
+}
+
+// CancelAll cancels all given objects that are not cancelled yet
+func (m *Manager) CancelAll(objects []Object) error {
+	for _, o := range objects {
+		if !o.Cancelled {
+			if err := m.Cancel(o.Index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
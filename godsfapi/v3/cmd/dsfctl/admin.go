@@ -0,0 +1,203 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/httpendpoints"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/usersessions"
+)
+
+func runPlugin(c *ctl, args []string) error {
+	if len(args) != 2 {
+		return usagef("Expected an action and a plugin")
+	}
+	action, plugin := args[0], args[1]
+	switch action {
+	case "install":
+		p, err := filepath.Abs(plugin)
+		if err != nil {
+			return err
+		}
+		plugin = p
+	case "start", "stop", "uninstall":
+	default:
+		return usagef("Unknown plugin action %s", action)
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	switch action {
+	case "install":
+		return cc.InstallPlugin(plugin)
+	case "start":
+		return cc.StartPlugin(plugin)
+	case "stop":
+		return cc.StopPlugin(plugin)
+	default:
+		return cc.UninstallPlugin(plugin)
+	}
+}
+
+func runSession(c *ctl, args []string) error {
+	if len(args) == 0 {
+		return usagef("Missing session action")
+	}
+	switch args[0] {
+	case "add":
+		return addSession(c, args[1:])
+	case "remove":
+		return removeSession(c, args[1:])
+	default:
+		return usagef("Unknown session action %s", args[0])
+	}
+}
+
+func addSession(c *ctl, args []string) error {
+	fs := newFlagSet("session add")
+	access := fs.String("access", string(usersessions.ReadWrite), "Access level of the session (readOnly or readWrite)")
+	sessionType := fs.String("type", string(usersessions.Local), "Type of the session (local, http or telnet)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return usagef("Expected an origin and an optional port")
+	}
+
+	var al usersessions.AccessLevel
+	switch strings.ToLower(*access) {
+	case strings.ToLower(string(usersessions.ReadOnly)):
+		al = usersessions.ReadOnly
+	case strings.ToLower(usersessions.ReadWrite):
+		al = usersessions.ReadWrite
+	default:
+		return usagef("Unknown access level %s", *access)
+	}
+	st := usersessions.SessionType(strings.ToLower(*sessionType))
+	switch st {
+	case usersessions.Local, usersessions.HTTP, usersessions.Telnet:
+	default:
+		return usagef("Unknown session type %s", *sessionType)
+	}
+	port := -1
+	if fs.NArg() == 2 {
+		p, err := strconv.Atoi(fs.Arg(1))
+		if err != nil {
+			return usagef("Invalid port %s", fs.Arg(1))
+		}
+		port = p
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	id, err := cc.AddUserSession(al, st, fs.Arg(0), port)
+	if err != nil {
+		return err
+	}
+	return c.print(map[string]int{"id": id}, func(w io.Writer) {
+		fmt.Fprintln(w, id)
+	})
+}
+
+func removeSession(c *ctl, args []string) error {
+	if len(args) != 1 {
+		return usagef("Expected a session ID")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usagef("Invalid session ID %s", args[0])
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	ok, err := cc.RemoveUserSession(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("Session %d could not be removed", id)
+	}
+	return nil
+}
+
+func runEndpoint(c *ctl, args []string) error {
+	if len(args) == 0 {
+		return usagef("Missing endpoint action")
+	}
+	switch args[0] {
+	case "list":
+		return listEndpoints(c, args[1:])
+	case "remove":
+		return removeEndpoint(c, args[1:])
+	default:
+		return usagef("Unknown endpoint action %s", args[0])
+	}
+}
+
+func listEndpoints(c *ctl, args []string) error {
+	if len(args) != 0 {
+		return usagef("Too many arguments")
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	m, err := cc.GetObjectModel()
+	if err != nil {
+		return err
+	}
+	endpoints := m.HttpEndpoints
+	if endpoints == nil {
+		endpoints = make([]httpendpoints.HttpEndpoint, 0)
+	}
+	return c.print(endpoints, func(w io.Writer) {
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "METHOD\tNAMESPACE\tPATH\tUPLOAD\tSOCKET")
+		for _, e := range endpoints {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\n", e.EndpointType, e.Namespace, e.Path, e.IsUploadRequest, e.UnixSocket)
+		}
+		tw.Flush()
+	})
+}
+
+func removeEndpoint(c *ctl, args []string) error {
+	if len(args) != 3 {
+		return usagef("Expected method, namespace and path")
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	t := httpendpoints.HttpEndpointType(strings.ToUpper(args[0]))
+	ok, err := cc.RemoveHttpEndpoint(t, args[1], args[2])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("Endpoint %s /machine/%s/%s could not be removed", t, args[1], args[2])
+	}
+	return nil
+}
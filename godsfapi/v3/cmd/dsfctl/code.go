@@ -0,0 +1,159 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/messages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// errCodeFailed is returned if at least one code reported an error
+var errCodeFailed = errors.New("Code execution reported an error")
+
+// parseChannel returns the code channel matching the given name (case-insensitive)
+func parseChannel(name string) (types.CodeChannel, error) {
+	for _, c := range types.AllChannels() {
+		if strings.EqualFold(string(c), name) {
+			return c, nil
+		}
+	}
+	return types.Unknown, usagef("Unknown code channel %s", name)
+}
+
+// codeResult is the JSON output of a single code
+type codeResult struct {
+	Code    string `json:"code"`
+	Result  string `json:"result"`
+	Success bool   `json:"success"`
+}
+
+// hasError checks if the given code reply contains an error message
+func hasError(reply string) bool {
+	for _, l := range strings.Split(reply, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(l), "Error:") {
+			return true
+		}
+	}
+	return false
+}
+
+func runCode(c *ctl, args []string) error {
+	fs := newFlagSet("code")
+	channel := fs.String("channel", string(types.DefaultChannel), "Code channel to execute the codes on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usagef("Missing code")
+	}
+	ch, err := parseChannel(*channel)
+	if err != nil {
+		return err
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	failed := false
+	for _, code := range fs.Args() {
+		reply, err := cc.PerformSimpleCode(code, ch)
+		if err != nil {
+			return err
+		}
+		cr := codeResult{Code: code, Result: strings.TrimRight(reply, "\n"), Success: !hasError(reply)}
+		failed = failed || !cr.Success
+		err = c.print(cr, func(w io.Writer) {
+			if cr.Result != "" {
+				fmt.Fprintln(w, cr.Result)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if failed {
+		return errCodeFailed
+	}
+	return nil
+}
+
+func runEval(c *ctl, args []string) error {
+	fs := newFlagSet("eval")
+	channel := fs.String("channel", string(types.DefaultChannel), "Code channel to evaluate the expression on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	expression := joinArgs(fs.Args())
+	if expression == "" {
+		return usagef("Missing expression")
+	}
+	ch, err := parseChannel(*channel)
+	if err != nil {
+		return err
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	result, err := cc.EvaluateExpression(ch, expression)
+	if err != nil {
+		return err
+	}
+	return c.print(result, func(w io.Writer) {
+		printValue(w, result)
+	})
+}
+
+func runMessage(c *ctl, args []string) error {
+	fs := newFlagSet("message")
+	mType := fs.String("type", "success", "Type of the message (success, warning or error)")
+	logLevel := fs.String("log", "", "Log level of the message (debug, info, warn or off)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	content := joinArgs(fs.Args())
+	if content == "" {
+		return usagef("Missing message")
+	}
+
+	var mt messages.MessageType
+	switch strings.ToLower(*mType) {
+	case "success":
+		mt = messages.Success
+	case "warning":
+		mt = messages.Warning
+	case "error":
+		mt = messages.Error
+	default:
+		return usagef("Unknown message type %s", *mType)
+	}
+	var ll *state.LogLevel
+	if *logLevel != "" {
+		l := state.LogLevel(strings.ToLower(*logLevel))
+		switch l {
+		case state.Debug, state.Info, state.Warn, state.LogLevelOff:
+		default:
+			return usagef("Unknown log level %s", *logLevel)
+		}
+		ll = &l
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	return cc.WriteTextMessage(mt, content, ll == nil, ll)
+}
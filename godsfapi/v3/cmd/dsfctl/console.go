@@ -0,0 +1,43 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"os"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/console"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+func runConsole(c *ctl, args []string) error {
+	fs := newFlagSet("console")
+	channel := fs.String("channel", string(types.DefaultChannel), "Code channel to execute the codes on")
+	historyFile := fs.String("history", "", "File to load and save the command history")
+	noColor := fs.Bool("no-color", false, "Do not colourize replies")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("Too many arguments")
+	}
+	ch, err := parseChannel(*channel)
+	if err != nil {
+		return err
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	con := console.NewConsole(cc, os.Stdin, c.out)
+	con.Channel = ch
+	con.Color = con.Color && !*noColor
+	if *historyFile != "" {
+		if err := con.Editor.History.Load(*historyFile); err != nil {
+			return err
+		}
+		defer con.Editor.History.Save(*historyFile)
+	}
+	return con.Run()
+}
@@ -0,0 +1,197 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+)
+
+// Exit codes of dsfctl
+const (
+	// ExitOk if the command was successful
+	ExitOk = 0
+	// ExitFailure if the command was rejected or reported an error
+	ExitFailure = 1
+	// ExitUsage if the command line could not be parsed
+	ExitUsage = 2
+	// ExitConnection if no connection to DuetControlServer could be established
+	ExitConnection = 3
+)
+
+// usageError is returned if a command was invoked with invalid arguments
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, a ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// connectionError is returned if the connection to DCS failed
+type connectionError struct {
+	err error
+}
+
+func (e *connectionError) Error() string { return "Failed to connect: " + e.err.Error() }
+
+func (e *connectionError) Unwrap() error { return e.err }
+
+// command is a single dsfctl sub-command
+type command struct {
+	// usage line without the command name
+	usage string
+	// description shown in the help output
+	description string
+	// run executes the command with the remaining arguments
+	run func(c *ctl, args []string) error
+}
+
+var commandList = map[string]command{
+	"console":  {"[-channel name] [-history file] [-no-color]", "Start an interactive G-code console", runConsole},
+	"code":     {"[-channel name] <code>...", "Execute G/M/T-codes", runCode},
+	"eval":     {"[-channel name] <expression>", "Evaluate an expression in RepRapFirmware", runEval},
+	"message":  {"[-type success|warning|error] [-log level] <text>", "Write a generic message", runMessage},
+	"model":    {"[path]", "Print the object model or a part of it", runModel},
+	"watch":    {"[-full] [filter]...", "Print object model updates as they are received", runWatch},
+	"fileinfo": {"<file>", "Print information about a G-code file", runFileInfo},
+	"plugin":   {"install <zip>|start <id>|stop <id>|uninstall <id>", "Manage plugins", runPlugin},
+	"session":  {"add [-access readOnly|readWrite] [-type local|http|telnet] <origin> [port]|remove <id>", "Manage user sessions", runSession},
+	"endpoint": {"list|remove <method> <namespace> <path>", "Manage third-party HTTP endpoints", runEndpoint},
+}
+
+// ctl holds the global settings of a dsfctl invocation
+type ctl struct {
+	socketPath string
+	json       bool
+	debug      bool
+	out        io.Writer
+}
+
+// commandConnection establishes a new command connection
+func (c *ctl) commandConnection() (*connection.CommandConnection, error) {
+	cc := &connection.CommandConnection{}
+	cc.Debug = c.debug
+	if err := cc.Connect(c.socketPath); err != nil {
+		cc.Close()
+		return nil, &connectionError{err: err}
+	}
+	connection.CloseOnSignals(cc)
+	return cc, nil
+}
+
+// print writes v as JSON or in human readable form depending on the settings
+func (c *ctl) print(v interface{}, human func(w io.Writer)) error {
+	if c.json {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.out, string(b))
+		return err
+	}
+	human(c.out)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	c := &ctl{out: stdout}
+	fs := flag.NewFlagSet("dsfctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.socketPath, "socket", connection.FullSocketPath, "Path to the DuetControlServer UNIX socket")
+	fs.BoolVar(&c.json, "json", false, "Print results as JSON")
+	fs.BoolVar(&c.debug, "debug", false, "Print debug messages")
+	fs.Usage = func() { printUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOk
+		}
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		printUsage(fs)
+		return ExitUsage
+	}
+
+	name := fs.Arg(0)
+	cmd, ok := commandList[name]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown command %s\n", name)
+		printUsage(fs)
+		return ExitUsage
+	}
+
+	err := cmd.run(c, fs.Args()[1:])
+	if err == nil {
+		return ExitOk
+	}
+	var ue *usageError
+	var ce *connectionError
+	switch {
+	case errors.As(err, &ue):
+		fmt.Fprintf(stderr, "%s\nUsage: dsfctl %s %s\n", err, name, cmd.usage)
+		return ExitUsage
+	case errors.As(err, &ce):
+		fmt.Fprintln(stderr, err)
+		return ExitConnection
+	default:
+		fmt.Fprintln(stderr, err)
+		return ExitFailure
+	}
+}
+
+func printUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: dsfctl [options] <command> [arguments]")
+	fmt.Fprintln(w, "\nOptions:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w, "\nCommands:")
+	names := make([]string, 0, len(commandList))
+	for n := range commandList {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(w, "  %-9s %s\n", n, commandList[n].description)
+		fmt.Fprintf(w, "  %-9s   dsfctl %s %s\n", "", n, commandList[n].usage)
+	}
+	fmt.Fprintf(w, "\nExit codes: %d success, %d failure, %d usage error, %d connection error\n",
+		ExitOk, ExitFailure, ExitUsage, ExitConnection)
+}
+
+// newFlagSet creates a flag set for a sub-command that reports errors as usageError
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	return fs
+}
+
+// parseFlags parses the given arguments and converts errors into usageError
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return usagef("Help requested")
+		}
+		return usagef("%s", err)
+	}
+	return nil
+}
+
+// joinArgs joins all remaining arguments to a single string
+func joinArgs(args []string) string {
+	return strings.TrimSpace(strings.Join(args, " "))
+}
@@ -0,0 +1,174 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection/initmessages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/filter"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/job"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/recording"
+)
+
+// printValue prints scalar values as they are and everything else as indented JSON
+func printValue(w io.Writer, v interface{}) {
+	switch t := v.(type) {
+	case nil:
+		fmt.Fprintln(w, "null")
+	case string:
+		fmt.Fprintln(w, t)
+	case bool, float64:
+		fmt.Fprintln(w, t)
+	default:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			fmt.Fprintln(w, v)
+			return
+		}
+		fmt.Fprintln(w, string(b))
+	}
+}
+
+func runModel(c *ctl, args []string) error {
+	if len(args) > 1 {
+		return usagef("Too many arguments")
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	r, err := cc.PerformCommand(commands.NewGetObjectModel())
+	if err != nil {
+		return err
+	}
+	v := r.GetResult()
+	if len(args) == 0 {
+		return c.print(v, func(w io.Writer) {
+			printValue(w, v)
+		})
+	}
+
+	p, err := query.Parse(args[0])
+	if err != nil {
+		return usagef("%s", err)
+	}
+	if !p.HasWildcard() {
+		if v, err = p.Get(v); err != nil {
+			return err
+		}
+		return c.print(v, func(w io.Writer) {
+			printValue(w, v)
+		})
+	}
+
+	matches, err := p.Query(v)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{}, len(matches))
+	for _, m := range matches {
+		values[m.Path.String()] = m.Value
+	}
+	return c.print(values, func(w io.Writer) {
+		for _, m := range matches {
+			fmt.Fprintf(w, "%s: ", m.Path)
+			printValue(w, m.Value)
+		}
+	})
+}
+
+func runWatch(c *ctl, args []string) error {
+	fs := newFlagSet("watch")
+	full := fs.Bool("full", false, "Receive the full object model on every update")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if _, err := filter.CompileAll(fs.Args()); err != nil {
+		return usagef("%s", err)
+	}
+	mode := initmessages.SubscriptionMode(initmessages.SubscriptionModePatch)
+	if *full {
+		mode = initmessages.SubscriptionModeFull
+	}
+
+	sc := &connection.SubscribeConnection{}
+	sc.Debug = c.debug
+	if err := sc.Connect(mode, fs.Args(), c.socketPath); err != nil {
+		sc.Close()
+		return &connectionError{err: err}
+	}
+	defer sc.Close()
+	connection.CloseOnSignals(sc)
+
+	kind := recording.EntryKind(recording.ModelEntry)
+	for {
+		j, err := sc.GetSerializedObjectModel()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		e := recording.Entry{Time: time.Now(), Kind: kind, Data: j}
+		err = c.print(e, func(w io.Writer) {
+			fmt.Fprintf(w, "%s %s\n", e.Time.Format("15:04:05.000"), string(j))
+		})
+		if err != nil {
+			return err
+		}
+		if mode == initmessages.SubscriptionModePatch {
+			kind = recording.PatchEntry
+		}
+	}
+}
+
+func runFileInfo(c *ctl, args []string) error {
+	if len(args) != 1 {
+		return usagef("Expected exactly one file")
+	}
+
+	cc, err := c.commandConnection()
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+
+	pfi, err := cc.GetFileInfo(args[0])
+	if err != nil {
+		return err
+	}
+	return c.print(pfi, func(w io.Writer) {
+		printFileInfo(w, pfi)
+	})
+}
+
+func printFileInfo(w io.Writer, pfi *job.ParsedFileInfo) {
+	fmt.Fprintf(w, "File:               %s\n", pfi.FileName)
+	fmt.Fprintf(w, "Size:               %d bytes\n", pfi.Size)
+	if pfi.LastModified != nil {
+		fmt.Fprintf(w, "Last modified:      %s\n", pfi.LastModified.Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "Generated by:       %s\n", pfi.GeneratedBy)
+	fmt.Fprintf(w, "Height:             %.2f mm\n", pfi.Height)
+	fmt.Fprintf(w, "First layer height: %.2f mm\n", pfi.FirstLayerHeight)
+	fmt.Fprintf(w, "Layer height:       %.2f mm\n", pfi.LayerHeight)
+	fmt.Fprintf(w, "Layers:             %d\n", pfi.NumLayers)
+	for i, f := range pfi.Filament {
+		fmt.Fprintf(w, "Filament %d:         %.1f mm\n", i, f)
+	}
+	if pfi.PrintTime != nil {
+		fmt.Fprintf(w, "Print time:         %s\n", time.Duration(*pfi.PrintTime)*time.Second)
+	}
+	if pfi.SimulatedTime != nil {
+		fmt.Fprintf(w, "Simulated time:     %s\n", time.Duration(*pfi.SimulatedTime)*time.Second)
+	}
+	fmt.Fprintf(w, "Thumbnails:         %d\n", len(pfi.Thumbnails))
+}
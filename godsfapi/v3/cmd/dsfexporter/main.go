@@ -0,0 +1,54 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/exporter"
+)
+
+func main() {
+	socketPath := flag.String("socket", connection.FullSocketPath, "Path to the DuetControlServer UNIX socket")
+	listen := flag.String("listen", "127.0.0.1:9877", "Address to serve metrics on")
+	metricsPath := flag.String("path", "/metrics", "HTTP path to serve metrics on")
+	reconnectDelay := flag.Duration("reconnect-delay", exporter.DefaultReconnectDelay, "Time to wait before reconnecting")
+	debug := flag.Bool("debug", false, "Print debug messages")
+	flag.Parse()
+
+	e := exporter.NewExporter(*socketPath)
+	e.ReconnectDelay = *reconnectDelay
+	e.Debug = *debug
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sc
+		cancel()
+	}()
+
+	go func() {
+		if err := e.Run(ctx); err != nil && err != context.Canceled {
+			log.Println("[ERROR] Subscription stopped:", err)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle(*metricsPath, e)
+	srv := &http.Server{Addr: *listen, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
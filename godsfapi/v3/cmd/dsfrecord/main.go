@@ -0,0 +1,55 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection/initmessages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/recording"
+)
+
+func main() {
+	socketPath := flag.String("socket", connection.FullSocketPath, "Path to the DuetControlServer UNIX socket")
+	output := flag.String("o", "", "File to write the recording to (default stdout)")
+	full := flag.Bool("full", false, "Record full object models instead of patches")
+	filters := flag.String("filters", "", "Comma-separated list of subscription filters")
+	debug := flag.Bool("debug", false, "Print debug messages")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	mode := initmessages.SubscriptionMode(initmessages.SubscriptionModePatch)
+	if *full {
+		mode = initmessages.SubscriptionModeFull
+	}
+	var f []string
+	if *filters != "" {
+		f = strings.Split(*filters, ",")
+	}
+
+	sc := connection.SubscribeConnection{}
+	sc.Debug = *debug
+	if err := sc.Connect(mode, f, *socketPath); err != nil {
+		log.Fatal(err)
+	}
+	defer sc.Close()
+	connection.CloseOnSignals(&sc)
+
+	r := recording.NewRecorder(w)
+	if err := r.Record(&sc); err != nil && err != io.EOF {
+		log.Println("[ERROR] Recording stopped:", err)
+	}
+}
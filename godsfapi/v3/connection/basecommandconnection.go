@@ -42,11 +42,12 @@ func (bcc *BaseCommandConnection) AddUserSession(access usersessions.AccessLevel
 	if originPort == -1 {
 		originPort = os.Getpid()
 	}
-	r, err := bcc.PerformCommand(commands.NewAddUserSession(access, t, origin, originPort))
+	var id int
+	_, err := bcc.PerformCommandResult(commands.NewAddUserSession(access, t, origin, originPort), &id)
 	if err != nil {
 		return -1, err
 	}
-	return r.GetResult().(int), nil
+	return id, nil
 }
 
 // CheckPassword checks the given password (see M551)
@@ -87,27 +88,24 @@ func (bcc *BaseCommandConnection) Flush(channel types.CodeChannel) (bool, error)
 
 // GetFileInfo gets the parsed G-code file information
 func (bcc *BaseCommandConnection) GetFileInfo(fileName string) (*job.ParsedFileInfo, error) {
-	r, err := bcc.PerformCommand(commands.NewGetFileInfo(fileName))
+	pfi := &job.ParsedFileInfo{}
+	_, err := bcc.PerformCommandResult(commands.NewGetFileInfo(fileName), pfi)
 	if err != nil {
 		return nil, err
 	}
-	pfi := r.GetResult().(job.ParsedFileInfo)
-	return &pfi, nil
+	return pfi, nil
 }
 
 // PerformCode executes an arbitrary pre-parsed code
 // Note that even with an error being nil the returned *commands.CodeResult
 // can also be nil, e.g. when sending Asynchronous commands that will only be queued and have no result yet.
 func (bcc *BaseCommandConnection) PerformCode(code *commands.Code) (*commands.CodeResult, error) {
-	r, err := bcc.PerformCommand(code)
+	var cr *commands.CodeResult
+	_, err := bcc.PerformCommandResult(code, &cr)
 	if err != nil {
 		return nil, err
 	}
-	if r.GetResult() != nil {
-		cr := r.GetResult().(commands.CodeResult)
-		return &cr, nil
-	}
-	return nil, nil
+	return cr, nil
 }
 
 // PerformSimpleCode executes an arbitrary G/M/T-code in text form and returns the result as a string
@@ -131,12 +129,12 @@ func (bcc *BaseCommandConnection) GetMachineModel() (*machine.MachineModel, erro
 // In subscription mode this is the first command that has to be called once a connection has
 // been established
 func (bcc *BaseCommandConnection) GetObjectModel() (*machine.MachineModel, error) {
-	r, err := bcc.PerformCommand(commands.NewGetObjectModel())
+	mm := machine.NewMachineModel()
+	_, err := bcc.PerformCommandResult(commands.NewGetObjectModel(), mm)
 	if err != nil {
 		return nil, err
 	}
-	mm := r.GetResult().(machine.MachineModel)
-	return &mm, nil
+	return mm, nil
 }
 
 // GetSerializedMachineModel fetches the machine model as UTF-8 JSON
@@ -220,6 +218,16 @@ func (bcc *BaseCommandConnection) UnlockObjectModel() error {
 	return err
 }
 
+// EvaluateExpression evaluates an arbitrary expression on the given channel in RepRapFirmware
+// and returns its result
+func (bcc *BaseCommandConnection) EvaluateExpression(channel types.CodeChannel, expression string) (interface{}, error) {
+	r, err := bcc.PerformCommand(commands.NewEvaluateExpression(channel, expression))
+	if err != nil {
+		return nil, err
+	}
+	return r.GetResult(), nil
+}
+
 // ResolvePath resolves a RepRapFirmware-style file path to a real file path
 func (bcc *BaseCommandConnection) ResolvePath(path string) (string, error) {
 	r, err := bcc.PerformCommand(commands.NewResolvePath(path))
@@ -30,6 +30,9 @@ const (
 	FullSocketPath = SocketDirectory + "/" + SocketFile
 )
 
+// ErrNotConnected is returned if data is sent on a connection that is not established
+var ErrNotConnected = errors.New("Connection is not established")
+
 // DecodeError is returned if a response from DCS could not be unmarshalled
 type DecodeError struct {
 	Target string
@@ -53,7 +56,7 @@ var conns []Closer
 func CloseOnSignals(c Closer) {
 	o.Do(func() {
 		conns = make([]Closer, 0)
-		sc := make(chan os.Signal)
+		sc := make(chan os.Signal, 1)
 		signal.Notify(sc, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
 		go func() {
 			<-sc
@@ -121,6 +124,12 @@ func (bc *BaseConnection) Connect(initMessage initmessages.ClientInitMessage, so
 	return nil
 }
 
+// Id returns the identifier the control server assigned to this connection.
+// Codes sent through this connection carry it as Code.SourceConnection.
+func (bc *BaseConnection) Id() int64 {
+	return bc.id
+}
+
 // Close the UNIX socket connection
 func (bc *BaseConnection) Close() error {
 	if bc == nil {
@@ -163,6 +172,26 @@ func (bc *BaseConnection) PerformCommand(command commands.Command) (commands.Res
 	return br, fmt.Errorf("InternalServerError: %s, %s, %s", command.GetCommand(), br.GetErrorType(), br.GetErrorMessage())
 }
 
+// PerformCommandResult performs an arbitrary command and unmarshals its result
+// into the given container
+func (bc *BaseConnection) PerformCommandResult(command commands.Command, resultContainer interface{}) (commands.Response, error) {
+	br, err := bc.PerformCommand(command)
+	if err != nil {
+		return br, err
+	}
+	b, err := json.Marshal(br.GetResult())
+	if err == nil {
+		err = json.Unmarshal(b, resultContainer)
+	}
+	if err != nil {
+		return br, &DecodeError{
+			Err:    err,
+			Target: fmt.Sprintf("%T", resultContainer),
+		}
+	}
+	return br, nil
+}
+
 // ReceiveResponse receives a deserialized response from the server
 func (bc *BaseConnection) ReceiveResponse() (commands.Response, error) {
 	br := &commands.BaseResponse{}
@@ -239,6 +268,10 @@ func (bc *BaseConnection) Send(data interface{}) error {
 	if bc.Debug {
 		log.Println("[DEBUG] <Send>", string(b))
 	}
-	_, err = bc.socket.Write(b)
+	socket := bc.socket
+	if socket == nil {
+		return ErrNotConnected
+	}
+	_, err = socket.Write(b)
 	return err
 }
@@ -2,11 +2,24 @@
 package connection
 
 import (
+	"encoding/json"
+
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection/initmessages"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
 )
 
+// ModelSubscription is implemented by all sources of object model updates
+// that behave like a SubscribeConnection
+type ModelSubscription interface {
+	// GetMachineModel retrieves the full object model
+	GetMachineModel() (*machine.MachineModel, error)
+	// GetMachineModelPatch receives the next (partial) object model update as JSON
+	GetMachineModelPatch() (string, error)
+	// Close the subscription
+	Close() error
+}
+
 // SubscribeConnection is used to subscribe for object model updates
 type SubscribeConnection struct {
 	BaseConnection
@@ -41,6 +54,20 @@ func (sc *SubscribeConnection) GetMachineModel() (*machine.MachineModel, error)
 	return m, nil
 }
 
+// GetSerializedObjectModel retrieves the full object model of the machine as UTF-8 JSON.
+// This is an alternative to GetMachineModel that preserves the data as sent by the server.
+func (sc *SubscribeConnection) GetSerializedObjectModel() (json.RawMessage, error) {
+	b, err := sc.ReceiveJson()
+	if err != nil {
+		return nil, err
+	}
+	err = sc.Send(commands.NewAcknowledge())
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
 // GetMachineModelPatch receives a (partial) machine model update as JSON UTF-8 string.
 // If the subscription mode is set to Patch, new update patches of the object model
 // need to be applied manually. This method is intended to receive such fragments.
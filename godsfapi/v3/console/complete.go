@@ -0,0 +1,179 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+)
+
+// ModelCacheTime is the time for which the object model used for completion is cached
+const ModelCacheTime = 2 * time.Second
+
+// MetaKeywords are the keywords of conditional G-code
+var MetaKeywords = []string{"abort", "break", "continue", "echo", "elif", "else", "global", "if", "set", "var", "while"}
+
+// CommonCodes are the G/M-codes offered for completion
+var CommonCodes = []string{
+	"G0", "G1", "G2", "G3", "G4", "G10", "G11", "G17", "G18", "G19", "G20", "G21", "G28", "G29", "G30", "G31", "G32",
+	"G53", "G54", "G55", "G56", "G57", "G58", "G59", "G60", "G90", "G91", "G92",
+	"M0", "M1", "M3", "M4", "M5", "M17", "M18", "M20", "M21", "M22", "M23", "M24", "M25", "M26", "M27", "M28", "M29",
+	"M30", "M32", "M36", "M37", "M38", "M80", "M81", "M82", "M83", "M84", "M92", "M98", "M99", "M104", "M106", "M107",
+	"M109", "M112", "M114", "M115", "M116", "M117", "M118", "M119", "M120", "M121", "M122", "M140", "M141", "M143",
+	"M190", "M191", "M200", "M201", "M203", "M204", "M207", "M208", "M220", "M221", "M226", "M280", "M290", "M291",
+	"M292", "M300", "M301", "M303", "M307", "M308", "M350", "M374", "M375", "M400", "M401", "M402", "M500", "M501",
+	"M502", "M550", "M552", "M557", "M558", "M563", "M564", "M566", "M568", "M569", "M572", "M574", "M584", "M591",
+	"M593", "M600", "M703", "M906", "M950", "M955", "M956", "M997", "M999",
+}
+
+// completer provides tab completion for codes, keywords and object model paths
+type completer struct {
+	connection *connection.CommandConnection
+
+	mu      sync.Mutex
+	model   interface{}
+	fetched time.Time
+}
+
+// getModel returns the cached object model and refreshes it if it is too old
+func (c *completer) getModel() interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.model == nil || time.Since(c.fetched) > ModelCacheTime {
+		r, err := c.connection.PerformCommand(commands.NewGetObjectModel())
+		if err == nil && r.IsSuccess() {
+			c.model = r.GetResult()
+			c.fetched = time.Now()
+		}
+	}
+	return c.model
+}
+
+// complete implements Completer
+func (c *completer) complete(line string) ([]string, int) {
+	start := len(line)
+	for start > 0 && isPathChar(line[start-1]) {
+		start--
+	}
+	word := line[start:]
+	before := strings.TrimSpace(line[:start])
+
+	if before == "" {
+		return completeFrom(word, CommonCodes, MetaKeywords), start
+	}
+	if strings.Count(line[:start], "{") > strings.Count(line[:start], "}") || isMetaLine(before) {
+		return completePath(c.getModel(), word), start
+	}
+	return nil, start
+}
+
+// isPathChar returns true if b may be part of a code or an object model path
+func isPathChar(b byte) bool {
+	return b == '.' || b == '[' || b == ']' || b == '_' ||
+		(b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// isMetaLine checks if the given line starts with a meta keyword
+func isMetaLine(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, k := range MetaKeywords {
+		if fields[0] == k {
+			return true
+		}
+	}
+	return false
+}
+
+// completeFrom returns all entries of the given lists that start with prefix (case-insensitive)
+func completeFrom(prefix string, lists ...[]string) []string {
+	var candidates []string
+	upper := strings.ToUpper(prefix)
+	for _, l := range lists {
+		for _, s := range l {
+			if strings.HasPrefix(strings.ToUpper(s), upper) {
+				candidates = append(candidates, s)
+			}
+		}
+	}
+	return candidates
+}
+
+// completePath completes a dot-separated object model path like move.axes[0].use
+func completePath(model interface{}, word string) []string {
+	if model == nil {
+		return nil
+	}
+	parent, partial := "", word
+	if i := strings.LastIndexAny(word, ".["); i >= 0 {
+		parent, partial = word[:i], word[i+1:]
+		if word[i] == '[' {
+			// Complete list indices
+			l, ok := resolve(model, parent).([]interface{})
+			if !ok {
+				return nil
+			}
+			var candidates []string
+			for idx := range l {
+				if s := strconv.Itoa(idx); strings.HasPrefix(s, partial) {
+					candidates = append(candidates, fmt.Sprintf("%s[%s]", parent, s))
+				}
+			}
+			return candidates
+		}
+	}
+
+	var v interface{} = model
+	if parent != "" {
+		v = resolve(model, parent)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if strings.HasPrefix(k, partial) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	candidates := make([]string, 0, len(keys))
+	for _, k := range keys {
+		c := k
+		if parent != "" {
+			c = parent + "." + k
+		}
+		switch m[k].(type) {
+		case map[string]interface{}:
+			if len(keys) == 1 {
+				c += "."
+			}
+		case []interface{}:
+			if len(keys) == 1 {
+				c += "["
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// resolve returns the value at the given path or nil if it does not exist
+func resolve(model interface{}, path string) interface{} {
+	v, err := query.Get(model, path)
+	if err != nil {
+		return nil
+	}
+	return v
+}
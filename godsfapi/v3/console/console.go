@@ -0,0 +1,243 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/messages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// BlockIndentation is the additional indentation inserted after a block header
+const BlockIndentation = "  "
+
+// ANSI escape sequences used for colourized output
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorYellow  = "\x1b[33m"
+	colorDefault = ""
+)
+
+// blockKeywords start a new block of conditional G-code
+var blockKeywords = []string{"if", "elif", "else", "while"}
+
+// Console is an interactive G-code console
+type Console struct {
+	// Connection used to execute codes
+	Connection *connection.CommandConnection
+	// Channel the codes are executed on
+	Channel types.CodeChannel
+	// Color enables colourized replies
+	Color bool
+	// Editor used to read lines
+	Editor *Editor
+
+	out io.Writer
+}
+
+// NewConsole creates a new console reading from in and writing to out.
+// Colours are enabled if in is a terminal.
+func NewConsole(cc *connection.CommandConnection, in *os.File, out io.Writer) *Console {
+	e := NewEditor(in, out)
+	c := &completer{connection: cc}
+	e.Complete = c.complete
+	return &Console{
+		Connection: cc,
+		Channel:    types.DefaultChannel,
+		Color:      e.IsTerminal(),
+		Editor:     e,
+		out:        out,
+	}
+}
+
+// Run reads and executes codes until the input ends or :quit is entered
+func (c *Console) Run() error {
+	if c.Editor.IsTerminal() {
+		c.Editor.Print("Connected to DuetControlServer. Type :help for help, :quit or Ctrl-D to exit.\n")
+	}
+	for {
+		line, err := c.Editor.ReadLine(c.prompt(), "")
+		if err == ErrInterrupted {
+			continue
+		} else if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, ":") {
+			quit, err := c.runMetaCommand(trimmed[1:])
+			if err != nil {
+				c.printMessage(messages.Message{Time: time.Now(), Type: messages.Error, Content: err.Error()})
+			}
+			if quit {
+				return nil
+			}
+			continue
+		}
+
+		code := line
+		if IsBlockHeader(line) {
+			if code, err = c.readBlock(line); err == ErrInterrupted {
+				continue
+			} else if err != nil && err != io.EOF {
+				return err
+			}
+		}
+		if err := c.execute(code); err != nil {
+			return err
+		}
+	}
+}
+
+// prompt returns the prompt showing the current code channel
+func (c *Console) prompt() string {
+	return string(c.Channel) + "> "
+}
+
+// readBlock reads the remaining lines of a block until an empty line is entered
+func (c *Console) readBlock(header string) (string, error) {
+	lines := []string{header}
+	indent := NextIndentation(header)
+	for {
+		line, err := c.Editor.ReadLine("... ", indent)
+		if err != nil {
+			return strings.Join(lines, "\n"), err
+		}
+		if strings.TrimSpace(line) == "" {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+		indent = NextIndentation(line)
+	}
+}
+
+// execute sends a code or block to DCS and prints the reply
+func (c *Console) execute(code string) error {
+	reply, err := c.Connection.PerformSimpleCode(code, c.Channel)
+	if err != nil {
+		return err
+	}
+	for _, m := range ParseReply(reply) {
+		c.printMessage(m)
+	}
+	return nil
+}
+
+// runMetaCommand executes a console command without the leading colon
+func (c *Console) runMetaCommand(command string) (bool, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false, fmt.Errorf("Missing command")
+	}
+	switch fields[0] {
+	case "quit", "exit":
+		return true, nil
+	case "help":
+		c.Editor.Print(helpText)
+	case "channel":
+		if len(fields) == 1 {
+			c.Editor.Print(string(c.Channel) + "\n")
+			return false, nil
+		}
+		for _, ch := range types.AllChannels() {
+			if strings.EqualFold(string(ch), fields[1]) {
+				c.Channel = ch
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("Unknown code channel %s", fields[1])
+	default:
+		return false, fmt.Errorf("Unknown command :%s", fields[0])
+	}
+	return false, nil
+}
+
+const helpText = `Enter G/M/T-codes to execute them on the selected channel.
+Lines starting with if, elif, else or while begin a block that is
+terminated by an empty line. Press Tab to complete codes and object
+model paths in expressions.
+
+  :channel [name]  Show or change the code channel
+  :help            Show this help
+  :quit            Exit the console
+`
+
+// printMessage writes a message in the colour of its type
+func (c *Console) printMessage(m messages.Message) {
+	s := m.String()
+	if c.Color {
+		if color := messageColor(m.Type); color != colorDefault {
+			s = color + s + colorReset
+		}
+	}
+	c.Editor.Print(s + "\n")
+}
+
+// messageColor returns the colour for the given message type
+func messageColor(t messages.MessageType) string {
+	switch t {
+	case messages.Error:
+		return colorRed
+	case messages.Warning:
+		return colorYellow
+	default:
+		return colorDefault
+	}
+}
+
+// ParseReply splits a code reply into messages. Lines following an
+// Error: or Warning: line belong to the same message.
+func ParseReply(reply string) []messages.Message {
+	var result []messages.Message
+	now := time.Now()
+	for _, l := range strings.Split(strings.TrimRight(reply, "\n"), "\n") {
+		t, content := messages.Success, l
+		if strings.HasPrefix(l, "Error: ") {
+			t, content = messages.Error, strings.TrimPrefix(l, "Error: ")
+		} else if strings.HasPrefix(l, "Warning: ") {
+			t, content = messages.Warning, strings.TrimPrefix(l, "Warning: ")
+		} else if len(result) > 0 {
+			result[len(result)-1].Content += "\n" + l
+			continue
+		}
+		if content == "" && t == messages.Success {
+			continue
+		}
+		result = append(result, messages.Message{Time: now, Type: t, Content: content})
+	}
+	return result
+}
+
+// IsBlockHeader checks if the given line starts a block of conditional G-code
+func IsBlockHeader(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, k := range blockKeywords {
+		if fields[0] == k {
+			return true
+		}
+	}
+	return false
+}
+
+// NextIndentation returns the indentation for the line following the given one
+func NextIndentation(line string) string {
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	if IsBlockHeader(line) {
+		indent += BlockIndentation
+	}
+	return indent
+}
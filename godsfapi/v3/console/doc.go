@@ -0,0 +1,11 @@
+/*
+Package console implements an interactive G-code console on top of a
+connection.CommandConnection.
+
+It provides line editing with history, multi-line entry of conditional G-code
+blocks, tab completion for common G/M-codes and object model paths as well as
+colourized replies. Raw terminal mode is only supported on Linux; on other
+platforms and for non-terminal input plain line-based input is used.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
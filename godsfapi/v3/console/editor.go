@@ -0,0 +1,343 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrInterrupted is returned by ReadLine if the user pressed Ctrl-C
+var ErrInterrupted = errors.New("Interrupted")
+
+// Completer returns possible completions for the text before the cursor.
+// Each candidate replaces line[start:].
+type Completer func(line string) (candidates []string, start int)
+
+// Key codes handled by the Editor
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyBackspace = 127
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyNewline   = 10
+)
+
+// Editor reads lines from a terminal with editing, history and completion support.
+// If the input is not a terminal lines are read as they are.
+type Editor struct {
+	// History of entered lines or nil to disable it
+	History *History
+	// Complete is called when Tab is pressed or nil to disable completion
+	Complete Completer
+
+	in     *os.File
+	r      *bufio.Reader
+	out    io.Writer
+	isTerm bool
+
+	mu     sync.Mutex
+	active bool
+	prompt string
+	buf    []rune
+	pos    int
+}
+
+// NewEditor creates a new Editor reading from in and writing to out
+func NewEditor(in *os.File, out io.Writer) *Editor {
+	return &Editor{
+		History: NewHistory(),
+		in:      in,
+		r:       bufio.NewReader(in),
+		out:     out,
+		isTerm:  isTerminal(int(in.Fd())),
+	}
+}
+
+// IsTerminal returns true if line editing is available
+func (e *Editor) IsTerminal() bool {
+	return e.isTerm
+}
+
+// ReadLine shows the prompt and reads a line pre-filled with initial.
+// It returns io.EOF if Ctrl-D is pressed on an empty line and ErrInterrupted on Ctrl-C.
+func (e *Editor) ReadLine(prompt, initial string) (string, error) {
+	if !e.isTerm {
+		return e.readPlainLine(prompt, initial)
+	}
+
+	state, err := makeRaw(int(e.in.Fd()))
+	if err != nil {
+		return e.readPlainLine(prompt, initial)
+	}
+	defer restoreTerminal(int(e.in.Fd()), state)
+
+	e.mu.Lock()
+	e.active = true
+	e.prompt = prompt
+	e.buf = []rune(initial)
+	e.pos = len(e.buf)
+	e.redraw()
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		e.active = false
+		e.mu.Unlock()
+	}()
+
+	histIndex, saved := -1, ""
+	if e.History != nil {
+		histIndex = e.History.Len()
+	}
+	for {
+		r, _, err := e.r.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		e.mu.Lock()
+		switch r {
+		case keyEnter, keyNewline:
+			line := string(e.buf)
+			fmt.Fprint(e.out, "\n")
+			e.mu.Unlock()
+			if e.History != nil {
+				e.History.Add(line)
+			}
+			return line, nil
+		case keyCtrlC:
+			fmt.Fprint(e.out, "^C\n")
+			e.mu.Unlock()
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(e.buf) == 0 {
+				fmt.Fprint(e.out, "\n")
+				e.mu.Unlock()
+				return "", io.EOF
+			}
+			e.delete()
+		case keyBackspace, keyCtrlH:
+			if e.pos > 0 {
+				e.pos--
+				e.delete()
+			}
+		case keyCtrlA:
+			e.pos = 0
+		case keyCtrlE:
+			e.pos = len(e.buf)
+		case keyCtrlB:
+			e.left()
+		case keyCtrlF:
+			e.right()
+		case keyCtrlK:
+			e.buf = e.buf[:e.pos]
+		case keyCtrlU:
+			e.buf = append([]rune{}, e.buf[e.pos:]...)
+			e.pos = 0
+		case keyCtrlW:
+			e.deleteWord()
+		case keyCtrlL:
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case keyCtrlP:
+			histIndex, saved = e.recall(histIndex-1, histIndex, saved)
+		case keyCtrlN:
+			histIndex, saved = e.recall(histIndex+1, histIndex, saved)
+		case keyTab:
+			e.complete()
+		case keyEscape:
+			switch e.readEscape() {
+			case 'A':
+				histIndex, saved = e.recall(histIndex-1, histIndex, saved)
+			case 'B':
+				histIndex, saved = e.recall(histIndex+1, histIndex, saved)
+			case 'C':
+				e.right()
+			case 'D':
+				e.left()
+			case 'H':
+				e.pos = 0
+			case 'F':
+				e.pos = len(e.buf)
+			case '3':
+				e.delete()
+			}
+		default:
+			if unicode.IsPrint(r) {
+				e.buf = append(e.buf[:e.pos], append([]rune{r}, e.buf[e.pos:]...)...)
+				e.pos++
+			}
+		}
+		e.redraw()
+		e.mu.Unlock()
+	}
+}
+
+// readPlainLine is used if the input is not a terminal
+func (e *Editor) readPlainLine(prompt, initial string) (string, error) {
+	e.mu.Lock()
+	fmt.Fprint(e.out, prompt)
+	e.mu.Unlock()
+	line, err := e.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if strings.TrimSpace(line) != "" {
+		line = initial + strings.TrimLeft(line, " \t")
+	}
+	return line, nil
+}
+
+// readEscape reads the rest of an escape sequence and returns its final character.
+// The delete key (ESC [ 3 ~) is reported as '3'.
+func (e *Editor) readEscape() rune {
+	r, _, err := e.r.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return 0
+	}
+	r, _, err = e.r.ReadRune()
+	if err != nil {
+		return 0
+	}
+	if r >= '0' && r <= '9' {
+		// Extended sequence terminated by ~
+		for {
+			t, _, err := e.r.ReadRune()
+			if err != nil || t == '~' {
+				break
+			}
+		}
+	}
+	return r
+}
+
+// Print writes text without disturbing the line currently being edited
+func (e *Editor) Print(text string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.active {
+		fmt.Fprint(e.out, "\r\x1b[K")
+		fmt.Fprint(e.out, text)
+		e.redraw()
+	} else {
+		fmt.Fprint(e.out, text)
+	}
+}
+
+func (e *Editor) left() {
+	if e.pos > 0 {
+		e.pos--
+	}
+}
+
+func (e *Editor) right() {
+	if e.pos < len(e.buf) {
+		e.pos++
+	}
+}
+
+// delete removes the character under the cursor
+func (e *Editor) delete() {
+	if e.pos < len(e.buf) {
+		e.buf = append(e.buf[:e.pos], e.buf[e.pos+1:]...)
+	}
+}
+
+// deleteWord removes the word before the cursor
+func (e *Editor) deleteWord() {
+	start := e.pos
+	for start > 0 && e.buf[start-1] == ' ' {
+		start--
+	}
+	for start > 0 && e.buf[start-1] != ' ' {
+		start--
+	}
+	e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+	e.pos = start
+}
+
+// recall replaces the buffer with the history entry at index i
+func (e *Editor) recall(i, current int, saved string) (int, string) {
+	if e.History == nil || i < 0 || i > e.History.Len() {
+		return current, saved
+	}
+	if current == e.History.Len() {
+		saved = string(e.buf)
+	}
+	if i == e.History.Len() {
+		e.buf = []rune(saved)
+	} else {
+		e.buf = []rune(e.History.Get(i))
+	}
+	e.pos = len(e.buf)
+	return i, saved
+}
+
+// complete performs tab completion on the text before the cursor
+func (e *Editor) complete() {
+	if e.Complete == nil {
+		return
+	}
+	before := string(e.buf[:e.pos])
+	candidates, start := e.Complete(before)
+	if len(candidates) == 0 || start < 0 || start > len(before) {
+		return
+	}
+
+	word := before[start:]
+	replacement := commonPrefix(candidates)
+	if len(candidates) > 1 && replacement == word {
+		sort.Strings(candidates)
+		fmt.Fprint(e.out, "\n"+strings.Join(candidates, "  ")+"\n")
+		return
+	}
+	if len(candidates) == 1 && strings.TrimSpace(before[:start]) == "" {
+		// Separate completed codes and keywords from their parameters
+		replacement += " "
+	}
+	rest := e.buf[e.pos:]
+	e.buf = append([]rune(before[:start]+replacement), rest...)
+	e.pos = len([]rune(before[:start] + replacement))
+}
+
+// redraw writes the prompt and buffer and positions the cursor
+func (e *Editor) redraw() {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.prompt, string(e.buf))
+	if n := len(e.buf) - e.pos; n > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", n)
+	}
+}
+
+// commonPrefix returns the longest common prefix of the given strings
+func commonPrefix(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	p := s[0]
+	for _, c := range s[1:] {
+		for !strings.HasPrefix(c, p) {
+			p = p[:len(p)-1]
+		}
+	}
+	return p
+}
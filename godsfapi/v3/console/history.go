@@ -0,0 +1,108 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// DefaultHistorySize is the default number of lines kept in a History
+const DefaultHistorySize = 1000
+
+// History keeps a list of previously entered lines
+type History struct {
+	// MaxSize is the maximum number of lines to keep
+	MaxSize int
+	lines   []string
+}
+
+// NewHistory creates a new empty History with DefaultHistorySize
+func NewHistory() *History {
+	return &History{MaxSize: DefaultHistorySize}
+}
+
+// Add appends a line to the history unless it is empty or equal to the last line
+func (h *History) Add(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if len(h.lines) > 0 && h.lines[len(h.lines)-1] == line {
+		return
+	}
+	h.lines = append(h.lines, line)
+	if h.MaxSize > 0 && len(h.lines) > h.MaxSize {
+		h.lines = h.lines[len(h.lines)-h.MaxSize:]
+	}
+}
+
+// Len returns the number of lines in the history
+func (h *History) Len() int {
+	return len(h.lines)
+}
+
+// Get returns the line at the given index where 0 is the oldest line
+func (h *History) Get(i int) string {
+	if i < 0 || i >= len(h.lines) {
+		return ""
+	}
+	return h.lines[i]
+}
+
+// Load reads the history from the given file. A missing file is not an error.
+func (h *History) Load(fileName string) error {
+	f, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		h.Add(unescapeHistoryLine(s.Text()))
+	}
+	return s.Err()
+}
+
+// Save writes the history to the given file
+func (h *History) Save(fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, l := range h.lines {
+		w.WriteString(escapeHistoryLine(l))
+		w.WriteByte('\n')
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// escapeHistoryLine makes multi-line blocks fit on a single line
+func escapeHistoryLine(l string) string {
+	l = strings.ReplaceAll(l, `\`, `\\`)
+	return strings.ReplaceAll(l, "\n", `\n`)
+}
+
+func unescapeHistoryLine(l string) string {
+	var b strings.Builder
+	for i := 0; i < len(l); i++ {
+		if l[i] == '\\' && i+1 < len(l) {
+			i++
+			if l[i] == 'n' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(l[i])
+			}
+			continue
+		}
+		b.WriteByte(l[i])
+	}
+	return b.String()
+}
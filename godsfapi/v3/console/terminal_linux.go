@@ -0,0 +1,61 @@
+//go:build linux
+// +build linux
+
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// terminalState holds the terminal settings to restore when leaving raw mode
+type terminalState struct {
+	termios syscall.Termios
+}
+
+func getTermios(fd int) (*syscall.Termios, error) {
+	t := &syscall.Termios{}
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TCGETS, uintptr(unsafe.Pointer(t))); e != 0 {
+		return nil, e
+	}
+	return t, nil
+}
+
+func setTermios(fd int, t *syscall.Termios) error {
+	if _, _, e := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TCSETS, uintptr(unsafe.Pointer(t))); e != 0 {
+		return e
+	}
+	return nil
+}
+
+// isTerminal checks if the given file descriptor refers to a terminal
+func isTerminal(fd int) bool {
+	_, err := getTermios(fd)
+	return err == nil
+}
+
+// makeRaw puts the terminal into raw mode and returns the previous state.
+// Output processing is left enabled so that newlines are still translated.
+func makeRaw(fd int) (*terminalState, error) {
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err = setTermios(fd, &raw); err != nil {
+		return nil, err
+	}
+	return &terminalState{termios: *old}, nil
+}
+
+// restoreTerminal restores a state previously returned by makeRaw
+func restoreTerminal(fd int, s *terminalState) error {
+	return setTermios(fd, &s.termios)
+}
@@ -0,0 +1,26 @@
+//go:build !linux
+// +build !linux
+
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package console
+
+import "errors"
+
+// errRawModeUnsupported is returned on platforms without raw mode support
+var errRawModeUnsupported = errors.New("Raw terminal mode is not supported on this platform")
+
+// terminalState is a placeholder on platforms without raw mode support
+type terminalState struct{}
+
+// isTerminal always returns false so that line-based input is used
+func isTerminal(fd int) bool {
+	return false
+}
+
+func makeRaw(fd int) (*terminalState, error) {
+	return nil, errRawModeUnsupported
+}
+
+func restoreTerminal(fd int, s *terminalState) error {
+	return errRawModeUnsupported
+}
@@ -0,0 +1,176 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+)
+
+// Version is an object model generation
+type Version int
+
+const (
+	// V1 is the object model of the root machine package
+	V1 Version = 1
+	// V2 is the object model of the v2 machine package
+	V2 = 2
+	// V3 is the object model of this module
+	V3 = 3
+)
+
+// String returns the name of this version
+func (v Version) String() string {
+	return fmt.Sprintf("v%d", int(v))
+}
+
+// Reason describes why a member could not be mapped
+type Reason string
+
+const (
+	// NoEquivalent if the v3 model has no corresponding member
+	NoEquivalent Reason = "no equivalent"
+	// TypeMismatch if the value has a different type than the v3 member
+	TypeMismatch = "type mismatch"
+	// UnknownValue if an enumeration value cannot be translated
+	UnknownValue = "unknown value"
+)
+
+// Unmapped is a member of the source model that is not part of the result
+type Unmapped struct {
+	// Path of the member. Members that are dropped while checking the result
+	// against the v3 schema are reported with their v3 path.
+	Path query.Path `json:"path"`
+	// Value of the member
+	Value interface{} `json:"value"`
+	// Reason why it was not mapped
+	Reason Reason `json:"reason"`
+}
+
+// Report lists everything that got lost during a conversion.
+// Members that are null, false, zero or empty are not reported.
+type Report struct {
+	// Version of the source model
+	Version Version `json:"version"`
+	// Unmapped members ordered by path
+	Unmapped []Unmapped `json:"unmapped"`
+}
+
+// Empty returns true if everything could be mapped
+func (r *Report) Empty() bool {
+	return len(r.Unmapped) == 0
+}
+
+// add records an unmapped member unless it carries no information
+func (r *Report) add(path query.Path, value interface{}, reason Reason) {
+	if isZero(value) {
+		return
+	}
+	r.Unmapped = append(r.Unmapped, Unmapped{Path: path, Value: value, Reason: reason})
+}
+
+// Detect determines the generation of a serialized object model.
+// Models without any distinguishing member are assumed to be v3.
+func Detect(data []byte) (Version, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return 0, err
+	}
+	return detect(root), nil
+}
+
+func detect(root map[string]interface{}) Version {
+	for _, key := range []string{"channels", "electronics", "storages", "messageBox", "lasers"} {
+		if _, ok := root[key]; ok {
+			return V1
+		}
+	}
+	if _, ok := root["plugins"]; ok {
+		return V3
+	}
+	if move, ok := root["move"].(map[string]interface{}); ok {
+		for _, key := range []string{"daa", "workspaceNumber"} {
+			if _, ok := move[key]; ok {
+				return V2
+			}
+		}
+		if comp, ok := move["compensation"].(map[string]interface{}); ok {
+			if grid, ok := comp["probeGrid"].(map[string]interface{}); ok {
+				if _, ok := grid["xMin"]; ok {
+					return V2
+				}
+			}
+		}
+	}
+	return V3
+}
+
+// Convert detects the generation of the given model and upgrades it to v3
+func Convert(data []byte) (*machine.MachineModel, *Report, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+	return convert(root, detect(root))
+}
+
+// FromV1 upgrades a serialized v1 object model
+func FromV1(data []byte) (*machine.MachineModel, *Report, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+	return convert(root, V1)
+}
+
+// FromV2 upgrades a serialized v2 object model
+func FromV2(data []byte) (*machine.MachineModel, *Report, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, nil, err
+	}
+	return convert(root, V2)
+}
+
+func convert(root map[string]interface{}, v Version) (*machine.MachineModel, *Report, error) {
+	c := &converter{report: &Report{Version: v, Unmapped: make([]Unmapped, 0)}}
+	src := object{m: root, c: c}
+	var out map[string]interface{}
+	switch v {
+	case V1:
+		out = c.fromV1(src)
+		c.leftovers(root, nil)
+	case V2:
+		out = c.fromV2(src)
+	case V3:
+		out = root
+	default:
+		return nil, nil, fmt.Errorf("Unsupported object model version %d", int(v))
+	}
+	c.checkSchema(out, modelType, nil)
+	sort.SliceStable(c.report.Unmapped, func(i, j int) bool {
+		return c.report.Unmapped[i].Path.String() < c.report.Unmapped[j].Path.String()
+	})
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := machine.NewMachineModel()
+	if err = json.Unmarshal(b, m); err != nil {
+		return nil, nil, err
+	}
+	return m, c.report, nil
+}
+
+// modelType is the schema converted models are checked against
+var modelType = reflect.TypeOf(machine.MachineModel{})
+
+// converter holds the state of a single conversion
+type converter struct {
+	report *Report
+}
@@ -0,0 +1,12 @@
+/*
+Package convert upgrades object model dumps of older DSF generations to the
+v3 machine.MachineModel.
+
+Version 1 models (the root machine package) are restructured completely, e.g.
+electronics become boards, storages become volumes and the channels map becomes
+the inputs list. Version 2 models only differ in a few members that are renamed
+or converted. Members that have no equivalent in the v3 model or whose values
+cannot be translated are left out and listed in the returned Report.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package convert
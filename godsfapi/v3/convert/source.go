@@ -0,0 +1,368 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package convert
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+)
+
+// object is a decoded JSON object of the source model. Mapped members are
+// removed so that only the unmapped ones remain at the end.
+type object struct {
+	path query.Path
+	m    map[string]interface{}
+	c    *converter
+}
+
+func (o object) keyPath(key string) query.Path {
+	return o.path.Append(query.Segment{Kind: query.Key, Key: key})
+}
+
+// resolve returns the name of the given member. Like the v1 decoder names are
+// matched case-insensitively if there is no exact match.
+func (o object) resolve(key string) string {
+	if _, ok := o.m[key]; ok {
+		return key
+	}
+	for k := range o.m {
+		if strings.EqualFold(k, key) {
+			return k
+		}
+	}
+	return key
+}
+
+// has returns true if the given member exists
+func (o object) has(key string) bool {
+	_, ok := o.m[o.resolve(key)]
+	return ok
+}
+
+// take removes the given member and returns its value
+func (o object) take(key string) (interface{}, bool) {
+	key = o.resolve(key)
+	v, ok := o.m[key]
+	if ok {
+		delete(o.m, key)
+	}
+	return v, ok
+}
+
+// str removes the given member and returns it if it is a string
+func (o object) str(key string) (string, bool) {
+	v, ok := o.take(key)
+	if !ok || v == nil {
+		return "", false
+	}
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	o.c.report.add(o.keyPath(key), v, TypeMismatch)
+	return "", false
+}
+
+// move maps the given member to dst using a new name
+func (o object) move(dst map[string]interface{}, key, newKey string) {
+	if v, ok := o.take(key); ok {
+		dst[newKey] = v
+	}
+}
+
+// copy maps the given members to dst using the same names
+func (o object) copy(dst map[string]interface{}, keys ...string) {
+	for _, k := range keys {
+		o.move(dst, k, k)
+	}
+}
+
+// number removes the given member and returns it if it is a number
+func (o object) number(key string) (float64, bool) {
+	v, ok := o.take(key)
+	if !ok || v == nil {
+		return 0, false
+	}
+	if f, ok := v.(float64); ok {
+		return f, true
+	}
+	o.c.report.add(o.keyPath(key), v, TypeMismatch)
+	return 0, false
+}
+
+// integer maps a number to dst rounding it to the nearest integer
+func (o object) integer(dst map[string]interface{}, key, newKey string) {
+	if v, ok := o.m[o.resolve(key)]; ok && v == nil {
+		o.take(key)
+		dst[newKey] = nil
+	} else if f, ok := o.number(key); ok {
+		dst[newKey] = math.Round(f)
+	}
+}
+
+// list removes the given member and returns it if it is a list
+func (o object) list(key string) []interface{} {
+	v, ok := o.take(key)
+	if !ok || v == nil {
+		return nil
+	}
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+	o.c.report.add(o.keyPath(key), v, TypeMismatch)
+	return nil
+}
+
+// enum removes a numeric enumeration value and returns its new name
+func (o object) enum(key string, names []string) (string, bool) {
+	f, ok := o.number(key)
+	if !ok {
+		return "", false
+	}
+	if i := int(f); float64(i) == f && i >= 0 && i < len(names) {
+		return names[i], true
+	}
+	o.c.report.add(o.keyPath(key), f, UnknownValue)
+	return "", false
+}
+
+// object returns the given member as object without removing it.
+// Other values are removed and reported.
+func (o object) object(key string) object {
+	key = o.resolve(key)
+	p := o.keyPath(key)
+	switch v := o.m[key].(type) {
+	case map[string]interface{}:
+		return object{path: p, m: v, c: o.c}
+	case nil:
+	default:
+		delete(o.m, key)
+		o.c.report.add(p, v, TypeMismatch)
+	}
+	return object{path: p, m: make(map[string]interface{}), c: o.c}
+}
+
+// objects returns the items of the given list member without removing it.
+// Items that are no objects are returned as empty objects and reported.
+func (o object) objects(key string) []object {
+	key = o.resolve(key)
+	p := o.keyPath(key)
+	l, ok := o.m[key].([]interface{})
+	if !ok {
+		if v := o.m[key]; v != nil {
+			delete(o.m, key)
+			o.c.report.add(p, v, TypeMismatch)
+		}
+		return nil
+	}
+	result := make([]object, len(l))
+	for i, item := range l {
+		ip := p.Append(query.Segment{Kind: query.Index, Index: i})
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			o.c.report.add(ip, item, TypeMismatch)
+			m = make(map[string]interface{})
+			l[i] = m
+		}
+		result[i] = object{path: ip, m: m, c: o.c}
+	}
+	return result
+}
+
+// child returns the object member of m with the given name and creates it if necessary
+func child(m map[string]interface{}, key string) map[string]interface{} {
+	if c, ok := m[key].(map[string]interface{}); ok {
+		return c
+	}
+	c := make(map[string]interface{})
+	m[key] = c
+	return c
+}
+
+// leftovers reports every member of the source model that has not been mapped
+func (c *converter) leftovers(v interface{}, path query.Path) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			c.leftovers(t[k], path.Append(query.Segment{Kind: query.Key, Key: k}))
+		}
+	case []interface{}:
+		for _, item := range t {
+			if _, ok := item.(map[string]interface{}); !ok {
+				// Lists of values are only mapped completely
+				c.report.add(path, t, NoEquivalent)
+				return
+			}
+		}
+		for i, item := range t {
+			c.leftovers(item, path.Append(query.Segment{Kind: query.Index, Index: i}))
+		}
+	default:
+		c.report.add(path, v, NoEquivalent)
+	}
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	variantType     = reflect.TypeOf((*query.Variant)(nil)).Elem()
+)
+
+// checkSchema removes and reports every member of v that cannot be decoded into
+// the given type. It returns false if v itself is incompatible.
+func (c *converter) checkSchema(v interface{}, t reflect.Type, path query.Path) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil || t.Kind() == reflect.Interface || t.Implements(variantType) {
+		// Variants choose their concrete type when they are decoded
+		return true
+	}
+	if t.Kind() == reflect.Struct && reflect.PtrTo(t).Implements(unmarshalerType) {
+		if _, ok := t.FieldByName("Extra"); !ok {
+			// Custom encoding like time.Time
+			return true
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		fields := jsonFields(t)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			p := path.Append(query.Segment{Kind: query.Key, Key: k})
+			ft, ok := fields[k]
+			if !ok {
+				c.report.add(p, m[k], NoEquivalent)
+				delete(m, k)
+			} else if !c.checkSchema(m[k], ft, p) {
+				c.report.add(p, m[k], TypeMismatch)
+				delete(m, k)
+			}
+		}
+		return true
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, item := range m {
+			p := path.Append(query.Segment{Kind: query.Key, Key: k})
+			if !c.checkSchema(item, t.Elem(), p) {
+				c.report.add(p, item, TypeMismatch)
+				delete(m, k)
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		l, ok := v.([]interface{})
+		if !ok {
+			return false
+		}
+		for i, item := range l {
+			p := path.Append(query.Segment{Kind: query.Index, Index: i})
+			if !c.checkSchema(item, t.Elem(), p) {
+				c.report.add(p, item, TypeMismatch)
+				l[i] = nil
+			}
+		}
+		return true
+	case reflect.String:
+		_, ok := v.(string)
+		return ok
+	case reflect.Bool:
+		_, ok := v.(bool)
+		return ok
+	case reflect.Float32, reflect.Float64:
+		_, ok := v.(float64)
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f) && f >= 0
+	default:
+		return false
+	}
+}
+
+// jsonFields returns the JSON-visible fields of a struct type by name
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	result := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			for k, ft := range jsonFields(sf.Type) {
+				result[k] = ft
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		result[name] = sf.Type
+	}
+	return result
+}
+
+// isZero returns true if v carries no information
+func isZero(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !t
+	case float64:
+		return t == 0
+	case string:
+		return t == ""
+	case []interface{}:
+		for _, item := range t {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		for _, item := range t {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// lowerFirst converts the first character to lower case like the v3 enumerations
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
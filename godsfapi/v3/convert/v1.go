@@ -0,0 +1,577 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package convert
+
+import (
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// v1Channels maps the members of the v1 channels object to v3 input channels
+var v1Channels = map[string]types.CodeChannel{
+	"http":      types.HTTP,
+	"telnet":    types.Telnet,
+	"file":      types.File,
+	"usb":       types.USB,
+	"aux":       types.Aux,
+	"trigger":   types.Trigger,
+	"codeQueue": types.Queue,
+	"lcd":       types.LCD,
+	"spi":       types.SBC,
+	"daemon":    types.Daemon,
+	"autoPause": types.AutoPause,
+}
+
+var (
+	// v1Compatibilities are the v3 names of the numeric v1 compatibility levels
+	v1Compatibilities = []string{"Default", "RepRapFirmware", "Marlin", "Teacup", "Sprinter", "Repetier", "NanoDLP"}
+	// v1HeaterStates are the v3 names of the numeric v1 heater states
+	v1HeaterStates = []string{"off", "standby", "active", "tuning", "offline"}
+	// v1EndstopTypes are the v3 names of the numeric v1 endstop types
+	v1EndstopTypes = []string{"inputPin", "inputPin", "zProbeAsEndstop", "motorStallAny", "motorStallIndividual"}
+	// v1ProbeTypes are the v3 probe types of the numeric v1 probe types
+	v1ProbeTypes = []float64{0, 1, 2, 5, 9, 10}
+	// v1KinematicsNames are the v3 names of the v1 kinematics types
+	v1KinematicsNames = map[string]string{
+		"cartesian":    "cartesian",
+		"corexy":       "coreXY",
+		"corexyu":      "coreXYU",
+		"corexyuv":     "coreXYUV",
+		"corexz":       "coreXZ",
+		"hangprinter":  "Hangprinter",
+		"delta":        "delta",
+		"polar":        "Polar",
+		"rotary delta": "Rotary delta",
+		"scara":        "Scara",
+		"unknown":      "unknown",
+	}
+	// v1EndpointTypes are the v3 names of the v1 HTTP endpoint types
+	v1EndpointTypes = map[string]string{
+		"get":       "GET",
+		"post":      "POST",
+		"put":       "PUT",
+		"patch":     "PATCH",
+		"trace":     "TRACE",
+		"delete":    "DELETE",
+		"options":   "OPTIONS",
+		"websocket": "WebSocket",
+	}
+)
+
+// fromV1 restructures a v1 model. Everything that is mapped is removed from root.
+func (c *converter) fromV1(root object) map[string]interface{} {
+	out := make(map[string]interface{})
+	c.v1Inputs(root.object("channels"), out)
+	c.v1Directories(root.object("directories"), out)
+	c.v1Boards(root.object("electronics"), out)
+	c.v1Fans(root.objects("fans"), out)
+	c.v1Heat(root.object("heat"), out)
+	c.v1HttpEndpoints(root.objects("httpEndpoints"), out)
+	c.v1Move(root.object("move"), out)
+	c.v1Job(root.object("job"), out)
+	root.copy(out, "messages", "scanner", "spindles", "userVariables")
+	c.v1Network(root.object("network"), out)
+	c.v1Sensors(root.object("sensors"), out)
+	c.v1State(root, out)
+	c.v1Volumes(root.objects("storages"), out)
+	c.v1Tools(root.objects("tools"), out)
+	c.v1UserSessions(root.objects("userSessions"), out)
+	return out
+}
+
+func (c *converter) v1Inputs(channels object, out map[string]interface{}) {
+	inputs := make([]interface{}, 0)
+	all := types.AllChannels()
+	for key, name := range v1Channels {
+		ch := channels.object(key)
+		if !channels.has(key) {
+			continue
+		}
+		input := map[string]interface{}{"name": string(name)}
+		if s, ok := ch.enum("compatibility", v1Compatibilities); ok {
+			input["compatibility"] = s
+		}
+		ch.move(input, "feedrate", "feedRate")
+		ch.move(input, "relativeExtrusion", "drivesRelative")
+		ch.move(input, "volumetricExtrusion", "volumetric")
+		ch.move(input, "relativePositioning", "axesRelative")
+		if v, ok := ch.take("usingInches"); ok {
+			if v == true {
+				input["distanceUnit"] = "Inch"
+			} else {
+				input["distanceUnit"] = "MM"
+			}
+		}
+		ch.copy(input, "stackDepth", "lineNumber")
+
+		// Inputs are indexed by their code channel
+		for i, n := range all {
+			if n == name {
+				for len(inputs) <= i {
+					inputs = append(inputs, nil)
+				}
+				inputs[i] = input
+			}
+		}
+	}
+	out["inputs"] = inputs
+}
+
+func (c *converter) v1Directories(dirs object, out map[string]interface{}) {
+	d := child(out, "directories")
+	dirs.copy(d, "filaments", "gCodes", "macros", "system", "menu")
+	dirs.move(d, "www", "web")
+}
+
+func (c *converter) v1Boards(e object, out map[string]interface{}) {
+	boards := make([]interface{}, 0)
+	if len(e.m) > 0 {
+		board := make(map[string]interface{})
+		e.copy(board, "shortName", "name", "vIn", "mcuTemp")
+		e.move(board, "processorId", "uniqueId")
+		v1Firmware(e.object("firmware"), board)
+		boards = append(boards, board)
+	}
+	for _, eb := range e.objects("expansionBoards") {
+		board := make(map[string]interface{})
+		eb.copy(board, "shortName", "name", "vIn", "mcuTemp", "maxHeaters", "maxMotors")
+		v1Firmware(eb.object("firmware"), board)
+		boards = append(boards, board)
+	}
+	out["boards"] = boards
+}
+
+func v1Firmware(fw object, board map[string]interface{}) {
+	fw.move(board, "name", "firmwareName")
+	fw.move(board, "version", "firmwareVersion")
+	fw.move(board, "date", "firmwareDate")
+}
+
+func (c *converter) v1Fans(fans []object, out map[string]interface{}) {
+	result := make([]interface{}, len(fans))
+	for i, f := range fans {
+		fan := make(map[string]interface{})
+		f.move(fan, "value", "requestedValue")
+		f.copy(fan, "name", "rpm", "frequency", "min", "max", "blip")
+		t := f.object("thermostatic")
+		thermostatic := child(fan, "thermostatic")
+		t.copy(thermostatic, "heaters")
+		t.move(thermostatic, "temperature", "lowTemperature")
+		result[i] = fan
+	}
+	out["fans"] = result
+}
+
+func (c *converter) v1Heat(h object, out map[string]interface{}) {
+	heat := child(out, "heat")
+	h.move(heat, "coldExtrusionTemp", "coldExtrudeTemperature")
+	h.move(heat, "coldRetractTemp", "coldRetractTemperature")
+	h.copy(heat, "coldExtrudeTemperature", "coldRetractTemperature")
+
+	heaters := make([]interface{}, 0)
+	for _, src := range h.objects("heaters") {
+		heater := make(map[string]interface{})
+		src.copy(heater, "current", "name", "max", "sensor")
+		if s, ok := src.enum("state", v1HeaterStates); ok {
+			heater["state"] = s
+		}
+		m := src.object("model")
+		model := child(heater, "model")
+		m.copy(model, "gain", "timeConstant", "deadTime", "maxPwm", "standardVoltage")
+		pid := child(model, "pid")
+		m.move(pid, "usePID", "used")
+		m.move(pid, "customPID", "overridden")
+		m.copy(pid, "p", "i", "d")
+		heaters = append(heaters, heater)
+	}
+	heat["beds"] = v1BedsOrChambers(h.objects("beds"), heaters)
+	heat["chamberHeaters"] = v1BedsOrChambers(h.objects("chambers"), heaters)
+	heat["heaters"] = heaters
+
+	// Extra heaters are reported as analog sensors in v3
+	analog := make([]interface{}, 0)
+	for _, e := range h.objects("extra") {
+		sensor := make(map[string]interface{})
+		e.move(sensor, "current", "lastReading")
+		e.copy(sensor, "name")
+		analog = append(analog, sensor)
+	}
+	child(out, "sensors")["analog"] = analog
+}
+
+// v1BedsOrChambers returns the heater numbers of the given beds or chambers and applies
+// their active and standby temperatures to the heaters. Beds or chambers with more than
+// one heater occupy one item per heater.
+func v1BedsOrChambers(items []object, heaters []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	for _, item := range items {
+		active, standby := item.list("active"), item.list("standby")
+		for i, h := range item.list("heaters") {
+			result = append(result, h)
+			n, ok := h.(float64)
+			if !ok || n < 0 || int(n) >= len(heaters) {
+				continue
+			}
+			heater := heaters[int(n)].(map[string]interface{})
+			if i < len(active) {
+				heater["active"] = active[i]
+			}
+			if i < len(standby) {
+				heater["standby"] = standby[i]
+			}
+		}
+	}
+	return result
+}
+
+func (c *converter) v1HttpEndpoints(endpoints []object, out map[string]interface{}) {
+	result := make([]interface{}, len(endpoints))
+	for i, e := range endpoints {
+		endpoint := make(map[string]interface{})
+		if v, ok := e.str("endpointType"); ok {
+			if t, ok := v1EndpointTypes[strings.ToLower(v)]; ok {
+				endpoint["endpointType"] = t
+			} else {
+				c.report.add(e.keyPath("endpointType"), v, UnknownValue)
+			}
+		}
+		e.copy(endpoint, "namespace", "path", "unixSocket")
+		result[i] = endpoint
+	}
+	out["httpEndpoints"] = result
+}
+
+func (c *converter) v1Move(mv object, out map[string]interface{}) {
+	move := child(out, "move")
+	compensation := child(move, "compensation")
+
+	axes := make([]interface{}, 0)
+	var axisDrives [][]interface{}
+	for _, a := range mv.objects("axes") {
+		axis := make(map[string]interface{})
+		a.copy(axis, "letter", "homed", "machinePosition", "min", "minProbed", "max", "maxProbed", "visible")
+		drives := a.list("drives")
+		axis["drivers"] = v1Drivers(drives)
+		axisDrives = append(axisDrives, drives)
+		axes = append(axes, axis)
+	}
+	if babystep, ok := mv.number("babystepZ"); ok {
+		for _, a := range axes {
+			if axis := a.(map[string]interface{}); axis["letter"] == "Z" {
+				axis["babystep"] = babystep
+			}
+		}
+	}
+	for w, coords := range mv.list("workplaceCoordinates") {
+		l, _ := coords.([]interface{})
+		for i, offset := range l {
+			if i < len(axes) {
+				axis := axes[i].(map[string]interface{})
+				offsets, _ := axis["workplaceOffsets"].([]interface{})
+				for len(offsets) <= w {
+					offsets = append(offsets, 0.0)
+				}
+				offsets[w] = offset
+				axis["workplaceOffsets"] = offsets
+			}
+		}
+	}
+	mv.move(move, "currentWorkplace", "workplaceNumber")
+
+	extruders := make([]interface{}, 0)
+	var extruderDrives [][]interface{}
+	for _, e := range mv.objects("extruders") {
+		extruder := make(map[string]interface{})
+		drives := e.list("drives")
+		if len(drives) > 0 {
+			extruder["driver"] = v1Drivers(drives[:1])[0]
+		}
+		extruderDrives = append(extruderDrives, drives)
+		e.copy(extruder, "factor")
+		n := e.object("nonLinear")
+		n.copy(child(extruder, "nonLinear"), "a", "b", "upperLimit")
+		extruders = append(extruders, extruder)
+	}
+
+	// Drive settings are stored per axis and extruder in v3
+	for i, d := range mv.objects("drives") {
+		target := v1DriveOwner(float64(i), axisDrives, axes)
+		isExtruder := false
+		if target == nil {
+			target = v1DriveOwner(float64(i), extruderDrives, extruders)
+			isExtruder = true
+		}
+		if target == nil {
+			continue
+		}
+		d.copy(target, "microstepping", "current", "acceleration")
+		d.move(target, "maxSpeed", "speed")
+		if isExtruder {
+			d.copy(target, "position")
+		}
+	}
+	move["axes"] = axes
+	move["extruders"] = extruders
+
+	if v, ok := mv.str("compensation"); ok {
+		switch strings.ToLower(v) {
+		case "none", "mesh":
+			compensation["type"] = strings.ToLower(v)
+		default:
+			// Three and four point compensation are not part of v3
+			c.report.add(mv.keyPath("compensation"), v, UnknownValue)
+		}
+	}
+	mv.move(compensation, "heightmapFile", "file")
+	if pg := mv.object("probeGrid"); len(pg.m) > 0 {
+		compensation["probeGrid"] = probeGrid(pg)
+	}
+
+	if g := mv.object("geometry"); len(g.m) > 0 {
+		move["kinematics"] = c.v1Kinematics(g)
+	}
+	mv.copy(move, "currentMove", "idle", "speedFactor")
+}
+
+// v1Drivers converts v1 drive numbers to v3 driver IDs
+func v1Drivers(drives []interface{}) []interface{} {
+	result := make([]interface{}, len(drives))
+	for i, d := range drives {
+		n, _ := d.(float64)
+		id := types.NewDriverIdUint64(uint64(n))
+		result[i] = map[string]interface{}{"Board": float64(id.Board), "Port": float64(id.Port)}
+	}
+	return result
+}
+
+// v1DriveOwner returns the axis or extruder using the given drive
+func v1DriveOwner(drive float64, drives [][]interface{}, owners []interface{}) map[string]interface{} {
+	for i, d := range drives {
+		for _, n := range d {
+			if n == drive {
+				return owners[i].(map[string]interface{})
+			}
+		}
+	}
+	return nil
+}
+
+func (c *converter) v1Kinematics(g object) map[string]interface{} {
+	k := make(map[string]interface{})
+	if t, ok := g.str("type"); ok {
+		if name, ok := v1KinematicsNames[strings.ToLower(t)]; ok {
+			k["name"] = name
+		} else {
+			c.report.add(g.keyPath("type"), t, UnknownValue)
+		}
+	}
+	switch k["name"] {
+	case "delta", "Rotary delta":
+		g.move(k, "radius", "deltaRadius")
+		g.copy(k, "homedHeight", "printRadius")
+		diagonals, corrections, adjustments := g.list("diagonals"), g.list("angleCorrections"), g.list("endstopAdjustments")
+		towers := make([]interface{}, len(diagonals))
+		for i := range towers {
+			tower := map[string]interface{}{"diagonal": diagonals[i]}
+			if i < len(corrections) {
+				tower["angleCorrection"] = corrections[i]
+			}
+			if i < len(adjustments) {
+				tower["endstopAdjustment"] = adjustments[i]
+			}
+			towers[i] = tower
+		}
+		k["towers"] = towers
+		if tilt := g.list("tilt"); len(tilt) == 2 {
+			k["xTilt"], k["yTilt"] = tilt[0], tilt[1]
+		}
+	case "Hangprinter":
+		g.copy(k, "printRadius")
+		if anchors := g.list("anchors"); len(anchors) == 10 {
+			k["anchorA"] = anchors[0:3]
+			k["anchorB"] = anchors[3:6]
+			k["anchorC"] = anchors[6:9]
+			k["anchorDz"] = anchors[9]
+		}
+	}
+	return k
+}
+
+// probeGrid converts a two-dimensional probe grid of v1 and v2
+func probeGrid(pg object) map[string]interface{} {
+	grid := make(map[string]interface{})
+	if pg.has("xMin") || pg.has("yMin") {
+		xMin, _ := pg.number("xMin")
+		xMax, _ := pg.number("xMax")
+		xSpacing, _ := pg.number("xSpacing")
+		yMin, _ := pg.number("yMin")
+		yMax, _ := pg.number("yMax")
+		ySpacing, _ := pg.number("ySpacing")
+		grid["axes"] = []interface{}{"X", "Y"}
+		grid["mins"] = []interface{}{xMin, yMin}
+		grid["maxs"] = []interface{}{xMax, yMax}
+		grid["spacings"] = []interface{}{xSpacing, ySpacing}
+	}
+	pg.copy(grid, "axes", "mins", "maxs", "spacings", "radius")
+	if spacing, ok := pg.number("spacing"); ok && grid["spacings"] == nil {
+		grid["spacings"] = []interface{}{spacing, spacing}
+	}
+	return grid
+}
+
+func (c *converter) v1Job(j object, out map[string]interface{}) {
+	job := child(out, "job")
+	j.copy(job, "file", "filePosition", "lastFileName", "lastFileAborted", "lastFileCancelled", "lastFileSimulated", "layerTime", "layers")
+	j.integer(job, "duration", "duration")
+	j.integer(job, "layer", "layer")
+	j.integer(job, "warmUpDuration", "warmUpDuration")
+	t := j.object("timesLeft")
+	timesLeft := child(job, "timesLeft")
+	for _, key := range []string{"file", "filament", "layer"} {
+		t.integer(timesLeft, key, key)
+	}
+
+	// Raw extruder positions belong to the extruders in v3
+	extruders, _ := child(out, "move")["extruders"].([]interface{})
+	raw := j.list("extrudedRaw")
+	for i, p := range raw {
+		if i >= len(extruders) {
+			// Keep the list so that it is reported
+			j.m["extrudedRaw"] = raw
+			break
+		}
+		extruders[i].(map[string]interface{})["rawPosition"] = p
+	}
+}
+
+func (c *converter) v1Network(n object, out map[string]interface{}) {
+	network := child(out, "network")
+	n.copy(network, "name", "hostname")
+	interfaces := make([]interface{}, 0)
+	for _, i := range n.objects("interfaces") {
+		iface := make(map[string]interface{})
+		i.copy(iface, "type", "firmwareVersion", "speed", "signal", "configuredIP", "actualIP", "subnet", "gateway", "numReconnnects", "activeProtocols")
+		i.move(iface, "macAddress", "mac")
+		i.move(iface, "numReconnects", "numReconnnects")
+		interfaces = append(interfaces, iface)
+	}
+	network["interfaces"] = interfaces
+}
+
+func (c *converter) v1Sensors(s object, out map[string]interface{}) {
+	sensors := child(out, "sensors")
+	endstops := make([]interface{}, 0)
+	for _, e := range s.objects("endstops") {
+		endstop := make(map[string]interface{})
+		e.copy(endstop, "triggered")
+		if t, ok := e.enum("type", v1EndstopTypes); ok {
+			endstop["type"] = t
+		}
+		endstops = append(endstops, endstop)
+	}
+	sensors["endstops"] = endstops
+
+	probes := make([]interface{}, 0)
+	for _, p := range s.objects("probes") {
+		probe := make(map[string]interface{})
+		if t, ok := p.number("type"); ok {
+			if i := int(t); float64(i) == t && i >= 0 && i < len(v1ProbeTypes) {
+				probe["type"] = v1ProbeTypes[i]
+			} else {
+				c.report.add(p.keyPath("type"), t, UnknownValue)
+			}
+		}
+		if v, ok := p.take("value"); ok {
+			value := []interface{}{v}
+			value = append(value, p.list("secondaryValues")...)
+			probe["value"] = value
+		}
+		if speed, ok := p.number("speed"); ok {
+			probe["speed"] = speed
+			probe["speeds"] = []interface{}{speed, speed}
+		}
+		p.copy(probe, "threshold", "diveHeight", "offsets", "triggerHeight", "recoveryTime", "travelSpeed", "maxProbeCount", "tolerance")
+		p.move(probe, "disablesBed", "disablesHeaters")
+		probes = append(probes, probe)
+	}
+	sensors["probes"] = probes
+}
+
+// v1State maps the state and the members of v1 that became part of it
+func (c *converter) v1State(root object, out map[string]interface{}) {
+	state := child(out, "state")
+	s := root.object("state")
+	s.copy(state, "atxPower", "currentTool", "displayMessage", "logFile")
+	b := s.object("beep")
+	beep := child(state, "beep")
+	b.copy(beep, "frequency")
+	b.integer(beep, "duration", "duration")
+	s.move(state, "mode", "machineMode")
+	if status, ok := s.str("status"); ok {
+		state["status"] = lowerFirst(status)
+	}
+
+	mb := root.object("messageBox")
+	if mode, ok := mb.take("mode"); ok && mode != nil {
+		box := map[string]interface{}{"mode": mode}
+		mb.copy(box, "title", "message", "seq")
+		var axisControls float64
+		for _, a := range mb.list("axisControls") {
+			if n, ok := a.(float64); ok {
+				axisControls += float64(uint64(1) << uint(n))
+			}
+		}
+		box["axisControls"] = axisControls
+		state["messageBox"] = box
+	} else if ok {
+		state["messageBox"] = nil
+	}
+
+	// v3 only knows a single laser
+	if lasers := root.objects("lasers"); len(lasers) > 0 {
+		lasers[0].move(state, "actualPwm", "laserPwm")
+	}
+}
+
+func (c *converter) v1Volumes(storages []object, out map[string]interface{}) {
+	volumes := make([]interface{}, len(storages))
+	for i, s := range storages {
+		volume := make(map[string]interface{})
+		s.copy(volume, "mounted", "speed", "capacity", "openFiles", "path")
+		s.move(volume, "free", "freeSpace")
+		volumes[i] = volume
+	}
+	out["volumes"] = volumes
+}
+
+func (c *converter) v1Tools(tools []object, out map[string]interface{}) {
+	extruders, _ := child(out, "move")["extruders"].([]interface{})
+	result := make([]interface{}, len(tools))
+	for i, t := range tools {
+		tool := make(map[string]interface{})
+		t.copy(tool, "number", "active", "standby", "name", "filamentExtruder", "fans", "heaters", "extruders", "mix", "spindle", "axes", "offsets", "offsetsProbed")
+
+		// Filaments are assigned to extruders in v3
+		if e, ok := tool["filamentExtruder"].(float64); ok && e >= 0 && int(e) < len(extruders) {
+			t.move(extruders[int(e)].(map[string]interface{}), "filament", "filament")
+		}
+		result[i] = tool
+	}
+	out["tools"] = result
+}
+
+func (c *converter) v1UserSessions(sessions []object, out map[string]interface{}) {
+	result := make([]interface{}, len(sessions))
+	for i, s := range sessions {
+		session := make(map[string]interface{})
+		s.copy(session, "id", "origin", "originId")
+		if v, ok := s.str("accessLevel"); ok {
+			session["accessLevel"] = lowerFirst(v)
+		}
+		if v, ok := s.str("sessionType"); ok {
+			session["sessionType"] = strings.ToLower(v)
+		}
+		result[i] = session
+	}
+	out["userSessions"] = result
+}
@@ -0,0 +1,46 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package convert
+
+// fromV2 converts the members of a v2 model that were renamed or restructured.
+// Everything else is passed through and checked against the v3 schema later.
+func (c *converter) fromV2(root object) map[string]interface{} {
+	mv := root.object("move")
+	if daa := mv.object("daa"); mv.has("daa") {
+		delete(mv.m, "daa")
+		if !mv.has("shaping") {
+			mv.m["shaping"] = v2Shaping(daa)
+		}
+		c.leftovers(daa.m, daa.path)
+	}
+	if mv.has("workspaceNumber") && !mv.has("workplaceNumber") {
+		mv.move(mv.m, "workspaceNumber", "workplaceNumber")
+	}
+	comp := mv.object("compensation")
+	if pg := comp.object("probeGrid"); len(pg.m) > 0 {
+		comp.m["probeGrid"] = probeGrid(pg)
+		c.leftovers(pg.m, pg.path)
+	}
+
+	for _, p := range root.object("sensors").objects("probes") {
+		if speed, ok := p.m["speed"]; ok && !p.has("speeds") {
+			p.m["speeds"] = []interface{}{speed, speed}
+		}
+		if coefficient, ok := p.m["temperatureCoefficient"]; ok && !p.has("temperatureCoefficients") {
+			p.m["temperatureCoefficients"] = []interface{}{coefficient}
+		}
+	}
+	return root.m
+}
+
+// v2Shaping converts the dynamic acceleration adjustment of v2 to input shaping
+func v2Shaping(daa object) map[string]interface{} {
+	shaping := map[string]interface{}{"type": "none"}
+	if enabled, _ := daa.take("enabled"); enabled == true {
+		shaping["type"] = "DAA"
+	}
+	if period, ok := daa.number("period"); ok && period > 0 {
+		shaping["frequency"] = 1 / period
+	}
+	daa.copy(shaping, "minimumAcceleration")
+	return shaping
+}
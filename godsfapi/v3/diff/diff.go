@@ -0,0 +1,224 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+)
+
+// Change is a single changed value
+type Change struct {
+	// Path of the changed value
+	Path query.Path `json:"path"`
+	// Old value or nil if it was added
+	Old interface{} `json:"old"`
+	// New value or nil if it was removed
+	New interface{} `json:"new"`
+}
+
+// Result holds the differences between two snapshots
+type Result struct {
+	// Patch turns the old snapshot into the new one or nil if both are equal
+	Patch map[string]interface{} `json:"patch"`
+	// Changes is the list of changed values ordered by path
+	Changes []Change `json:"changes"`
+}
+
+// Empty returns true if there are no differences
+func (r *Result) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// PatchJSON returns the serialized patch
+func (r *Result) PatchJSON() ([]byte, error) {
+	if r.Patch == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(r.Patch)
+}
+
+// Diff compares two snapshots, e.g. of type *machine.MachineModel. Both are
+// compared by their JSON representation.
+func Diff(old, new interface{}) (*Result, error) {
+	o, err := normalize(old)
+	if err != nil {
+		return nil, err
+	}
+	n, err := normalize(new)
+	if err != nil {
+		return nil, err
+	}
+	return diffValues(o, n), nil
+}
+
+// DiffJSON compares two serialized snapshots
+func DiffJSON(old, new []byte) (*Result, error) {
+	var o, n interface{}
+	if err := json.Unmarshal(old, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(new, &n); err != nil {
+		return nil, err
+	}
+	return diffValues(o, n), nil
+}
+
+// normalize converts v into its decoded JSON representation
+func normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	err = json.Unmarshal(b, &result)
+	return result, err
+}
+
+func diffValues(old, new interface{}) *Result {
+	r := &Result{Changes: make([]Change, 0)}
+	om, ok1 := old.(map[string]interface{})
+	nm, ok2 := new.(map[string]interface{})
+	if !ok1 || !ok2 {
+		// Patches are always objects so replace the root completely
+		if !reflect.DeepEqual(old, new) {
+			r.Changes = append(r.Changes, Change{Old: old, New: new})
+			if nm != nil {
+				r.Patch = nm
+			}
+		}
+		return r
+	}
+	if p, changed := diffMaps(om, nm, nil, &r.Changes); changed {
+		r.Patch = p.(map[string]interface{})
+	}
+	return r
+}
+
+// diffValue returns the patch turning old into new and whether there is any change
+func diffValue(old, new interface{}, path query.Path, changes *[]Change) (interface{}, bool) {
+	switch n := new.(type) {
+	case map[string]interface{}:
+		if o, ok := old.(map[string]interface{}); ok {
+			return diffMaps(o, n, path, changes)
+		}
+	case []interface{}:
+		if o, ok := old.([]interface{}); ok {
+			return diffLists(o, n, path, changes)
+		}
+	}
+	if reflect.DeepEqual(old, new) {
+		return nil, false
+	}
+	*changes = append(*changes, Change{Path: path, Old: old, New: new})
+	return new, true
+}
+
+func diffMaps(old, new map[string]interface{}, path query.Path, changes *[]Change) (interface{}, bool) {
+	keys := make([]string, 0, len(old)+len(new))
+	for k := range new {
+		keys = append(keys, k)
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	patch := make(map[string]interface{})
+	for _, k := range keys {
+		childPath := path.Append(query.Segment{Kind: query.Key, Key: k})
+		o, inOld := old[k]
+		n, inNew := new[k]
+		switch {
+		case !inNew:
+			// Removed keys are reported as null like DCS does for removed map entries
+			patch[k] = nil
+			*changes = append(*changes, Change{Path: childPath, Old: o})
+		case !inOld:
+			patch[k] = n
+			*changes = append(*changes, Change{Path: childPath, New: n})
+		default:
+			if p, changed := diffValue(o, n, childPath, changes); changed {
+				patch[k] = p
+			}
+		}
+	}
+	return patch, len(patch) > 0
+}
+
+// diffLists creates a list patch. Patched lists always have the length of the new
+// list and object items are patched individually, so unchanged object items become
+// empty objects. Lists of other values are sent completely if anything changed.
+func diffLists(old, new []interface{}, path query.Path, changes *[]Change) (interface{}, bool) {
+	patch := make([]interface{}, len(new))
+	changed := len(old) != len(new)
+	complete := false
+	for i, n := range new {
+		itemPath := path.Append(query.Segment{Kind: query.Index, Index: i})
+		if i >= len(old) {
+			patch[i] = n
+			*changes = append(*changes, Change{Path: itemPath, New: n})
+			continue
+		}
+		_, oldIsObject := old[i].(map[string]interface{})
+		_, newIsObject := n.(map[string]interface{})
+		if oldIsObject && newIsObject {
+			p, itemChanged := diffValue(old[i], n, itemPath, changes)
+			if !itemChanged {
+				p = make(map[string]interface{})
+			}
+			patch[i] = p
+			changed = changed || itemChanged
+		} else {
+			p, itemChanged := diffValue(old[i], n, itemPath, changes)
+			if itemChanged {
+				patch[i] = p
+				changed = true
+				complete = complete || !newIsObject
+			} else {
+				patch[i] = n
+			}
+		}
+	}
+	for i := len(new); i < len(old); i++ {
+		*changes = append(*changes, Change{Path: path.Append(query.Segment{Kind: query.Index, Index: i}), Old: old[i]})
+	}
+	if complete {
+		return new, true
+	}
+	return patch, changed
+}
+
+// Tracker keeps the last snapshot and reports differences to the next one.
+// This is useful to generate patches when polling full object models.
+type Tracker struct {
+	mu   sync.Mutex
+	last interface{}
+}
+
+// NewTracker creates a new Tracker without a previous snapshot
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Update compares the given snapshot with the previous one and stores it.
+// The first call reports the whole snapshot as added.
+func (t *Tracker) Update(snapshot interface{}) (*Result, error) {
+	n, err := normalize(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	old := t.last
+	if old == nil {
+		old = make(map[string]interface{})
+	}
+	t.last = n
+	return diffValues(old, n), nil
+}
@@ -0,0 +1,10 @@
+/*
+Package diff generates object model patches from two snapshots.
+
+It is the inverse of MachineModel.UpdateFromJson: the generated patch has the
+format DuetControlServer sends in SubscriptionModePatch and turns the old
+snapshot into the new one when applied. In addition every changed value is
+reported with its path and its old and new values.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package diff
@@ -0,0 +1,9 @@
+/*
+Package exporter provides a Prometheus/OpenMetrics exporter for printer telemetry.
+
+An Exporter subscribes to the object model of DuetControlServer, keeps a local
+copy of it up to date and serves selected values in the Prometheus text exposition
+format. Lost connections are re-established automatically.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package exporter
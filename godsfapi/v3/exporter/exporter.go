@@ -0,0 +1,321 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package exporter
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection/initmessages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/sensors"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+)
+
+const (
+	// DefaultNamespace is prefixed to every metric name
+	DefaultNamespace = "dsf_"
+	// DefaultReconnectDelay is the time to wait before a lost connection is re-established
+	DefaultReconnectDelay = 5 * time.Second
+	// ContentType of the served metrics
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// DefaultFilters are the subscription filters covering all exported values
+func DefaultFilters() []string {
+	return []string{
+		"boards/**",
+		"fans/**",
+		"heat/**",
+		"job/**",
+		"move/axes/**",
+		"sensors/filamentMonitors/**",
+		"state/status",
+		"volumes/**",
+	}
+}
+
+// heaterStates are all states exported for a heater
+var heaterStates = []heat.HeaterState{heat.Off, heat.Standby, heat.Active, heat.Fault, heat.Tuning, heat.Offline}
+
+// machineStates are all states exported for the machine
+var machineStates = []state.MachineStatus{state.Updating, state.Off, state.Halted, state.Pausing, state.Paused,
+	state.Resuming, state.Processing, state.Simulating, state.Busy, state.ChangingTool, state.Idle}
+
+// filamentMonitorStates are all states exported for a filament monitor
+var filamentMonitorStates = []sensors.FilamentMonitorStatus{sensors.NoMonitor, sensors.Ok, sensors.NoDataReceived,
+	sensors.NoFilament, sensors.TooLittleMovement, sensors.TooMuchMovement, sensors.SensorError}
+
+// Exporter subscribes to the object model and serves it as metrics.
+// It implements http.Handler so it can be registered with any http.ServeMux.
+type Exporter struct {
+	// SocketPath of DuetControlServer
+	SocketPath string
+	// Filters used for the subscription
+	Filters []string
+	// Namespace is prefixed to every metric name
+	Namespace string
+	// ReconnectDelay is the time to wait before a lost connection is re-established
+	ReconnectDelay time.Duration
+	// Debug enables logging of connection events
+	Debug bool
+	// Connect opens the subscription. If nil a connection.SubscribeConnection
+	// to SocketPath is established. This can be used to export recorded sessions.
+	Connect func() (connection.ModelSubscription, error)
+
+	mu         sync.RWMutex
+	model      *machine.MachineModel
+	lastUpdate time.Time
+	reconnects uint64
+}
+
+// NewExporter creates a new Exporter for the given socket path using default settings
+func NewExporter(socketPath string) *Exporter {
+	return &Exporter{
+		SocketPath:     socketPath,
+		Filters:        DefaultFilters(),
+		Namespace:      DefaultNamespace,
+		ReconnectDelay: DefaultReconnectDelay,
+	}
+}
+
+// Run maintains the subscription until the given context is done.
+// Whenever the connection is lost it is re-established after ReconnectDelay.
+func (e *Exporter) Run(ctx context.Context) error {
+	for {
+		err := e.subscribe(ctx)
+		e.setModel(nil)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if e.Debug {
+			log.Println("[DEBUG] <Exporter> Connection lost:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.ReconnectDelay):
+		}
+
+		e.mu.Lock()
+		e.reconnects++
+		e.mu.Unlock()
+	}
+}
+
+// connect establishes a new subscription to DCS
+func (e *Exporter) connect() (connection.ModelSubscription, error) {
+	sc := &connection.SubscribeConnection{}
+	sc.Debug = e.Debug
+	err := sc.Connect(initmessages.SubscriptionModePatch, e.Filters, e.SocketPath)
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+	return sc, nil
+}
+
+// subscribe connects to DCS and applies received patches until an error occurs
+func (e *Exporter) subscribe(ctx context.Context) error {
+	connect := e.Connect
+	if connect == nil {
+		connect = e.connect
+	}
+	sc, err := connect()
+	if err != nil {
+		return err
+	}
+
+	// Close the connection on cancellation to unblock pending reads
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		sc.Close()
+	}()
+
+	m, err := sc.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	e.setModel(m)
+
+	for {
+		patch, err := sc.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		e.mu.Lock()
+		err = e.model.UpdateFromJson([]byte(patch))
+		e.lastUpdate = time.Now()
+		e.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (e *Exporter) setModel(m *machine.MachineModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.model = m
+	e.lastUpdate = time.Now()
+}
+
+// ServeHTTP writes the current metrics
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	if err := e.WriteMetrics(w); err != nil && e.Debug {
+		log.Println("[DEBUG] <Exporter> Failed to write metrics:", err)
+	}
+}
+
+// WriteMetrics writes all metrics in the Prometheus text exposition format to w
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	mw := newMetricWriter(w, e.Namespace)
+	mw.sample("exporter_reconnects_total", Counter, "Number of times the connection to DCS was re-established", float64(e.reconnects))
+	mw.gauge("up", "Whether the object model subscription is established", boolValue(e.model != nil))
+	if e.model != nil {
+		mw.gauge("last_update_timestamp_seconds", "Time of the last object model update", float64(e.lastUpdate.UnixNano())/1e9)
+		writeModel(mw, e.model)
+	}
+	return mw.flush()
+}
+
+// writeModel writes all metrics derived from the object model
+func writeModel(mw *metricWriter, m *machine.MachineModel) {
+	writeHeat(mw, m)
+	writeFans(mw, m)
+	writeBoards(mw, m)
+	writeAxes(mw, m)
+	writeJob(mw, m)
+	writeFilamentMonitors(mw, m)
+	writeVolumes(mw, m)
+	for _, s := range machineStates {
+		mw.gauge("machine_status", "Current status of the machine", boolValue(m.State.Status == s), Label{"status", string(s)})
+	}
+}
+
+func writeHeat(mw *metricWriter, m *machine.MachineModel) {
+	for i, h := range m.Heat.Heaters {
+		if h.State == nil {
+			// Heater is not configured
+			continue
+		}
+		labels := []Label{{"heater", strconv.Itoa(i)}, {"name", h.Name}}
+		mw.gauge("heater_current_celsius", "Current temperature of the heater", h.Current, labels...)
+		mw.gauge("heater_active_celsius", "Active temperature of the heater", h.Active, labels...)
+		mw.gauge("heater_standby_celsius", "Standby temperature of the heater", h.Standby, labels...)
+		for _, s := range heaterStates {
+			mw.gauge("heater_state", "Current state of the heater", boolValue(h.State != nil && *h.State == s),
+				append(labels, Label{"state", string(s)})...)
+		}
+	}
+}
+
+func writeFans(mw *metricWriter, m *machine.MachineModel) {
+	for i, f := range m.Fans {
+		labels := []Label{{"fan", strconv.Itoa(i)}, {"name", f.Name}}
+		mw.gauge("fan_rpm", "Current RPM of the fan or -1 if unknown", float64(f.Rpm), labels...)
+		mw.gauge("fan_actual_value", "Current speed of the fan on a scale between 0 and 1 or -1 if unknown", f.ActualValue, labels...)
+		mw.gauge("fan_requested_value", "Requested speed of the fan on a scale between 0 and 1", f.RequestedValue, labels...)
+	}
+}
+
+func writeBoards(mw *metricWriter, m *machine.MachineModel) {
+	for i, b := range m.Boards {
+		labels := []Label{{"board", strconv.Itoa(i)}, {"name", b.ShortName}}
+		if b.VIn != nil {
+			mw.gauge("board_vin_volts", "Input voltage of the board", b.VIn.Current, labels...)
+		}
+		if b.V12 != nil {
+			mw.gauge("board_v12_volts", "Voltage of the 12V rail of the board", b.V12.Current, labels...)
+		}
+		if b.McuTemp != nil {
+			mw.gauge("board_mcu_temperature_celsius", "MCU temperature of the board", b.McuTemp.Current, labels...)
+		}
+	}
+}
+
+func writeAxes(mw *metricWriter, m *machine.MachineModel) {
+	for _, a := range m.Move.Axes {
+		labels := []Label{{"axis", a.Letter}}
+		if a.UserPosition != nil {
+			mw.gauge("axis_user_position_mm", "User position of the axis", *a.UserPosition, labels...)
+		}
+		if a.MachinePosition != nil {
+			mw.gauge("axis_machine_position_mm", "Machine position of the axis", *a.MachinePosition, labels...)
+		}
+		mw.gauge("axis_homed", "Whether the axis is homed", boolValue(a.Homed), labels...)
+	}
+}
+
+func writeJob(mw *metricWriter, m *machine.MachineModel) {
+	j := m.Job
+	if j.FilePosition != nil && j.File.Size > 0 {
+		mw.gauge("job_progress_ratio", "Fraction of the job file processed", float64(*j.FilePosition)/float64(j.File.Size))
+	}
+	if j.Duration != nil {
+		mw.gauge("job_duration_seconds", "Total duration of the current job", float64(*j.Duration))
+	}
+	if j.PauseDuration != nil {
+		mw.gauge("job_pause_duration_seconds", "Total pause time of the current job", float64(*j.PauseDuration))
+	}
+	timesLeft := []struct {
+		source string
+		value  *int64
+	}{
+		{"file", j.TimesLeft.File},
+		{"filament", j.TimesLeft.Filament},
+		{"slicer", j.TimesLeft.Slicer},
+	}
+	for _, tl := range timesLeft {
+		if tl.value != nil {
+			mw.gauge("job_time_left_seconds", "Estimated time left of the current job", float64(*tl.value), Label{"source", tl.source})
+		}
+	}
+}
+
+func writeFilamentMonitors(mw *metricWriter, m *machine.MachineModel) {
+	for i, f := range m.Sensors.FilamentMonitors {
+		if f.Value == nil {
+			continue
+		}
+		bfm, err := m.Sensors.FilamentMonitors.GetAsBaseFilamentMonitor(i)
+		if err != nil {
+			continue
+		}
+		labels := []Label{{"monitor", strconv.Itoa(i)}, {"type", string(bfm.Type)}}
+		mw.gauge("filament_monitor_enabled", "Whether the filament monitor is enabled", boolValue(bfm.Enabled), labels...)
+		for _, s := range filamentMonitorStates {
+			mw.gauge("filament_monitor_status", "Last reported status of the filament monitor", boolValue(bfm.Status == s),
+				append(labels, Label{"status", string(s)})...)
+		}
+	}
+}
+
+func writeVolumes(mw *metricWriter, m *machine.MachineModel) {
+	for i, v := range m.Volumes {
+		labels := []Label{{"volume", strconv.Itoa(i)}, {"path", v.Path}}
+		mw.gauge("volume_mounted", "Whether the volume is mounted", boolValue(v.Mounted), labels...)
+		if v.Capacity > 0 {
+			mw.gauge("volume_capacity_bytes", "Total capacity of the volume", float64(v.Capacity), labels...)
+		}
+		if v.FreeSpace != nil {
+			mw.gauge("volume_free_bytes", "Free space of the volume", float64(*v.FreeSpace), labels...)
+		}
+	}
+}
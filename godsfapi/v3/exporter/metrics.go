@@ -0,0 +1,130 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package exporter
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the type of a metric family
+type MetricType string
+
+const (
+	// Gauge is a value that can go up and down
+	Gauge MetricType = "gauge"
+	// Counter is a value that only ever increases
+	Counter MetricType = "counter"
+)
+
+// Label is a name-value pair attached to a sample
+type Label struct {
+	// Name of the label
+	Name string
+	// Value of the label
+	Value string
+}
+
+// metricWriter writes samples in the Prometheus text exposition format.
+// Samples are buffered per family so that the samples of a family form a single
+// group preceded by its HELP and TYPE lines, even if they are written interleaved.
+type metricWriter struct {
+	w        *bufio.Writer
+	prefix   string
+	families map[string]*family
+	order    []*family
+}
+
+// family holds the buffered samples of a metric family
+type family struct {
+	name    string
+	t       MetricType
+	help    string
+	samples strings.Builder
+}
+
+func newMetricWriter(w io.Writer, prefix string) *metricWriter {
+	return &metricWriter{
+		w:        bufio.NewWriter(w),
+		prefix:   prefix,
+		families: make(map[string]*family),
+	}
+}
+
+// sample adds a single sample of the given family
+func (mw *metricWriter) sample(name string, t MetricType, help string, value float64, labels ...Label) {
+	name = mw.prefix + name
+	f := mw.families[name]
+	if f == nil {
+		f = &family{name: name, t: t, help: help}
+		mw.families[name] = f
+		mw.order = append(mw.order, f)
+	}
+	b := &f.samples
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l.Name)
+			b.WriteString(`="`)
+			b.WriteString(escapeLabelValue(l.Value))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte('\n')
+}
+
+// gauge is a shorthand for a sample of type Gauge
+func (mw *metricWriter) gauge(name, help string, value float64, labels ...Label) {
+	mw.sample(name, Gauge, help, value, labels...)
+}
+
+// flush writes all families in the order they were first written to the underlying io.Writer
+func (mw *metricWriter) flush() error {
+	for _, f := range mw.order {
+		mw.w.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		mw.w.WriteString("# TYPE " + f.name + " " + string(f.t) + "\n")
+		mw.w.WriteString(f.samples.String())
+	}
+	mw.order = nil
+	mw.families = make(map[string]*family)
+	return mw.w.Flush()
+}
+
+func escapeHelp(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+func escapeLabelValue(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package exporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+)
+
+func TestFamiliesAreGrouped(t *testing.T) {
+	m := machine.NewMachineModel()
+	patch := `{"heat":{"heaters":[{"name":"bed","state":"active","current":60},null,{"name":"e0","state":"off","current":25}]}}`
+	if err := m.UpdateFromJson([]byte(patch)); err != nil {
+		t.Fatalf("Failed to update model: %v", err)
+	}
+
+	var b bytes.Buffer
+	mw := newMetricWriter(&b, "dsf_")
+	writeHeat(mw, m)
+	if err := mw.flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	last := ""
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := line[:strings.IndexAny(line, "{ ")]
+		if name != last {
+			if seen[name] {
+				t.Errorf("Samples of %s are not contiguous", name)
+			}
+			seen[name] = true
+			last = name
+		}
+		if strings.Contains(line, `name=""`) {
+			t.Errorf("Unconfigured heater was exported: %s", line)
+		}
+	}
+	if !strings.Contains(b.String(), `dsf_heater_current_celsius{heater="2",name="e0"} 25`) {
+		t.Errorf("Missing sample of heater 2 in\n%s", b.String())
+	}
+}
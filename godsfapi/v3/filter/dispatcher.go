@@ -0,0 +1,104 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package filter
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Handler is called with the pruned patch of a consumer
+type Handler func(patch []byte)
+
+// consumer is a registered Handler with its filters
+type consumer struct {
+	id      int
+	filters Set
+	handler Handler
+}
+
+// Dispatcher distributes the patches of one wide subscription among
+// several consumers with narrower filters
+type Dispatcher struct {
+	mu        sync.Mutex
+	nextID    int
+	consumers []consumer
+}
+
+// NewDispatcher creates a new Dispatcher without consumers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe registers a handler for the given filters and returns its ID.
+// An empty set receives every patch.
+func (d *Dispatcher) Subscribe(filters Set, handler Handler) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.nextID++
+	d.consumers = append(d.consumers, consumer{id: d.nextID, filters: filters, handler: handler})
+	return d.nextID
+}
+
+// Unsubscribe removes the handler with the given ID
+func (d *Dispatcher) Unsubscribe(id int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, c := range d.consumers {
+		if c.id == id {
+			d.consumers = append(d.consumers[:i], d.consumers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Filters returns the expressions of all consumers which can be used for
+// the wide subscription. Nil is returned if any consumer requires every patch.
+func (d *Dispatcher) Filters() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var result []string
+	seen := make(map[string]bool)
+	for _, c := range d.consumers {
+		if len(c.filters) == 0 {
+			return nil
+		}
+		for _, e := range c.filters.Expressions() {
+			if !seen[e] {
+				seen[e] = true
+				result = append(result, e)
+			}
+		}
+	}
+	return result
+}
+
+// Dispatch passes the relevant parts of the given patch to every consumer.
+// Handlers are called synchronously in the order they were registered.
+func (d *Dispatcher) Dispatch(patch []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(patch, &v); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	consumers := make([]consumer, len(d.consumers))
+	copy(consumers, d.consumers)
+	d.mu.Unlock()
+
+	for _, c := range consumers {
+		if len(c.filters) == 0 {
+			c.handler(patch)
+			continue
+		}
+		pruned, ok := c.filters.Prune(v)
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(pruned)
+		if err != nil {
+			return err
+		}
+		c.handler(b)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+/*
+Package filter compiles subscription filter expressions and applies them to
+object model patches locally.
+
+Filter expressions use the syntax of initmessages.SubscribeInitMessage.Filters,
+e.g. heat/heaters[*]/current or heat/**. Compiled filters are validated against
+the schema of machine.MachineModel so that typos are reported instead of silently
+yielding no updates. A Set of filters can prune a JSON patch to the parts it
+selects, which allows a single wide subscription to be shared between several
+consumers with narrower filters by means of a Dispatcher.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package filter
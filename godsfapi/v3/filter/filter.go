@@ -0,0 +1,230 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package filter
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/query"
+)
+
+// Delimiters are the characters separating multiple filters in the deprecated
+// SubscribeInitMessage.Filter field
+const Delimiters = "|, \r\n"
+
+// modelType is the schema filters are validated against
+var modelType = reflect.TypeOf(machine.MachineModel{})
+
+// Filter is a compiled filter expression
+type Filter struct {
+	// Expression this filter was compiled from
+	Expression string
+	// Path of this filter
+	Path query.Path
+}
+
+// Compile parses the given expression and validates it against the object model.
+// Unknown segments are reported as *query.PathError.
+func Compile(expression string) (*Filter, error) {
+	p, err := query.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.CheckType(modelType); err != nil {
+		return nil, err
+	}
+	return &Filter{Expression: expression, Path: p}, nil
+}
+
+// MustCompile is like Compile but panics if the expression is invalid
+func MustCompile(expression string) *Filter {
+	f, err := Compile(expression)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Split splits a delimited filter string as used by SubscribeInitMessage.Filter
+func Split(filter string) []string {
+	return strings.FieldsFunc(filter, func(r rune) bool {
+		return strings.ContainsRune(Delimiters, r)
+	})
+}
+
+// String returns the expression of this filter
+func (f *Filter) String() string {
+	return f.Expression
+}
+
+// Matches checks if a change of the value at the given concrete path is relevant
+// for this filter, i.e. if the path lies below a selected value or is a parent of one
+func (f *Filter) Matches(path query.Path) bool {
+	for i, s := range path {
+		if i == len(f.Path) || f.Path[i].Kind == query.Recursive {
+			return true
+		}
+		if !segmentMatches(f.Path[i], s) {
+			return false
+		}
+	}
+	return true
+}
+
+// Prune returns the parts of a decoded JSON patch selected by this filter
+// and false if nothing is left
+func (f *Filter) Prune(patch interface{}) (interface{}, bool) {
+	return Set{f}.Prune(patch)
+}
+
+// segmentMatches checks if filter segment f selects the concrete segment s
+func segmentMatches(f, s query.Segment) bool {
+	if s.Kind == query.Key {
+		// Numeric keys may refer to list items
+		if i, err := strconv.Atoi(s.Key); err == nil {
+			if (f.Kind == query.Index && f.Index == i) || f.Kind == query.AnyIndex {
+				return true
+			}
+		}
+	}
+	switch f.Kind {
+	case query.Key:
+		return (s.Kind == query.Key && s.Key == f.Key) || (s.Kind == query.Index && strconv.Itoa(s.Index) == f.Key)
+	case query.Index:
+		return s.Kind == query.Index && s.Index == f.Index
+	case query.AnyIndex:
+		return s.Kind == query.Index
+	default:
+		return true
+	}
+}
+
+// Set is a list of filters that select the union of their values
+type Set []*Filter
+
+// CompileAll compiles all given expressions
+func CompileAll(expressions []string) (Set, error) {
+	s := make(Set, 0, len(expressions))
+	for _, e := range expressions {
+		f, err := Compile(e)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, f)
+	}
+	return s, nil
+}
+
+// Expressions returns the expressions of all filters
+func (s Set) Expressions() []string {
+	result := make([]string, len(s))
+	for i, f := range s {
+		result[i] = f.Expression
+	}
+	return result
+}
+
+// Matches checks if a change at the given path is relevant for any filter
+func (s Set) Matches(path query.Path) bool {
+	for _, f := range s {
+		if f.Matches(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune returns the parts of a decoded JSON patch selected by any filter
+// and false if nothing is left. Values that replace a whole selected subtree,
+// like null, are kept. List items that are not selected are replaced by empty
+// objects or null so that the indices of the remaining items do not change.
+// An empty set selects everything.
+func (s Set) Prune(patch interface{}) (interface{}, bool) {
+	if len(s) == 0 {
+		return patch, true
+	}
+	cursors := make([]cursor, len(s))
+	for i, f := range s {
+		cursors[i] = cursor{path: f.Path}
+	}
+	return prune(patch, cursors)
+}
+
+// PruneJSON is like Prune but operates on raw JSON
+func (s Set) PruneJSON(patch []byte) ([]byte, bool, error) {
+	var v interface{}
+	if err := json.Unmarshal(patch, &v); err != nil {
+		return nil, false, err
+	}
+	pruned, ok := s.Prune(v)
+	if !ok {
+		return nil, false, nil
+	}
+	b, err := json.Marshal(pruned)
+	return b, err == nil, err
+}
+
+// cursor is the position within a filter path while pruning
+type cursor struct {
+	path query.Path
+	pos  int
+}
+
+// done returns true if the rest of the value is selected completely
+func (c cursor) done() bool {
+	return c.pos == len(c.path) || c.path[c.pos].Kind == query.Recursive
+}
+
+// advance returns the cursors that continue below the given child segment
+func advance(cursors []cursor, s query.Segment) []cursor {
+	var result []cursor
+	for _, c := range cursors {
+		if segmentMatches(c.path[c.pos], s) {
+			result = append(result, cursor{path: c.path, pos: c.pos + 1})
+		}
+	}
+	return result
+}
+
+func prune(v interface{}, cursors []cursor) (interface{}, bool) {
+	for _, c := range cursors {
+		if c.done() {
+			return v, true
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k, child := range t {
+			if next := advance(cursors, query.Segment{Kind: query.Key, Key: k}); len(next) > 0 {
+				if pruned, ok := prune(child, next); ok {
+					result[k] = pruned
+				}
+			}
+		}
+		return result, len(result) > 0
+	case []interface{}:
+		result := make([]interface{}, len(t))
+		kept := false
+		for i, child := range t {
+			if next := advance(cursors, query.Segment{Kind: query.Index, Index: i}); len(next) > 0 {
+				if pruned, ok := prune(child, next); ok {
+					result[i] = pruned
+					kept = true
+					continue
+				}
+			}
+			if _, isObject := child.(map[string]interface{}); isObject {
+				result[i] = make(map[string]interface{})
+			}
+		}
+		return result, kept
+	default:
+		// Scalars and null replace everything below them
+		return v, true
+	}
+}
@@ -1,5 +1,3 @@
 module github.com/Duet3D/DSF-APIs/godsfapi/v3
 
 go 1.14
-
-require github.com/mitchellh/mapstructure v1.2.2
@@ -0,0 +1,13 @@
+/*
+Package heatmon detects heater faults on the client side.
+
+RepRapFirmware detects heater faults itself. This package adds an independent
+safety layer that watches the heater readings of the object model and reports
+heaters that heat up too slowly compared to their heater model, exceed their
+maximum temperature, deviate from their setpoint for too long or have a sensor
+that is stuck or reads absolute zero. The heater monitors configured by M143 are
+evaluated as well. Faults are passed to a callback and may trigger an emergency
+stop (M112).
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatmon
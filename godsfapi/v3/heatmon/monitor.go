@@ -0,0 +1,421 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatmon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// FaultKind identifies the condition of a fault
+type FaultKind string
+
+const (
+	// HeatingTooSlow if the temperature rises slower than the heater model predicts
+	HeatingTooSlow FaultKind = "heatingTooSlow"
+	// Overshoot if the temperature exceeds the maximum of the heater
+	Overshoot = "overshoot"
+	// Deviation if the temperature deviates from the setpoint for too long
+	Deviation = "deviation"
+	// SensorStuck if the reading does not change while the heater is driven
+	SensorStuck = "sensorStuck"
+	// SensorFault if the reading is at or below absolute zero or not a number
+	SensorFault = "sensorFault"
+	// MonitorTriggered if the condition of a heater monitor is met
+	MonitorTriggered = "monitorTriggered"
+)
+
+// Default values for Config
+const (
+	DefaultMinHeatingRateFraction = 0.25
+	DefaultHeatingCheckPeriod     = 20 * time.Second
+	DefaultMaxDeviation           = 15.0
+	DefaultDeviationTime          = 5 * time.Second
+	DefaultStuckTime              = 30 * time.Second
+	DefaultAmbientTemperature     = 25.0
+	DefaultCheckInterval          = time.Second
+)
+
+// Config holds the thresholds of the fault detection
+type Config struct {
+	// MinHeatingRateFraction is the fraction of the heating rate predicted by the
+	// heater model that must be reached while heating up
+	MinHeatingRateFraction float64
+	// HeatingCheckPeriod is the period over which the heating rate is measured
+	HeatingCheckPeriod time.Duration
+	// MaxDeviation is the permitted deviation from the setpoint (in K) like M570 T
+	MaxDeviation float64
+	// DeviationTime is how long the deviation may be exceeded like M570 H
+	DeviationTime time.Duration
+	// StuckTime is how long the reading may stay unchanged while heating or cooling
+	StuckTime time.Duration
+	// AmbientTemperature used to predict the heating rate (in degC)
+	AmbientTemperature float64
+	// CheckInterval is how often Watch checks the heaters even if no patches arrive
+	CheckInterval time.Duration
+}
+
+// DefaultConfig returns the default thresholds
+func DefaultConfig() Config {
+	return Config{
+		MinHeatingRateFraction: DefaultMinHeatingRateFraction,
+		HeatingCheckPeriod:     DefaultHeatingCheckPeriod,
+		MaxDeviation:           DefaultMaxDeviation,
+		DeviationTime:          DefaultDeviationTime,
+		StuckTime:              DefaultStuckTime,
+		AmbientTemperature:     DefaultAmbientTemperature,
+		CheckInterval:          DefaultCheckInterval,
+	}
+}
+
+// Fault is a detected heater fault
+type Fault struct {
+	// Time of the reading that caused the fault
+	Time time.Time `json:"time"`
+	// Heater is the index of the heater
+	Heater int `json:"heater"`
+	// Name of the heater
+	Name string `json:"name"`
+	// Kind of the fault
+	Kind FaultKind `json:"kind"`
+	// Temperature that was read (in degC)
+	Temperature float64 `json:"temperature"`
+	// Target temperature or nil if the heater is off
+	Target *float64 `json:"target"`
+	// Monitor is the index of the triggered heater monitor or -1
+	Monitor int `json:"monitor"`
+	// Action of the triggered heater monitor or nil
+	Action *heat.HeaterMonitorAction `json:"action"`
+}
+
+func (f Fault) String() string {
+	s := fmt.Sprintf("Heater %d %s at %.1fC", f.Heater, f.Kind, f.Temperature)
+	if f.Target != nil {
+		s += fmt.Sprintf(" (target %.1fC)", *f.Target)
+	}
+	if f.Monitor >= 0 {
+		s += fmt.Sprintf(" by monitor %d", f.Monitor)
+	}
+	return s
+}
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// Monitor keeps track of the heaters and detects faults. Every fault is
+// reported once until its condition is no longer met.
+type Monitor struct {
+	// Config holds the thresholds
+	Config Config
+	// EmergencyStop receives M112 on every fault if set
+	EmergencyStop CodePerformer
+
+	mu       sync.Mutex
+	heaters  []heat.Heater
+	trackers []tracker
+}
+
+// tracker holds the history of a single heater
+type tracker struct {
+	target      *float64
+	reached     bool
+	heating     bool
+	windowTime  time.Time
+	windowTemp  float64
+	heatingFrom time.Time
+	deviating   time.Time
+	lastValue   float64
+	lastChange  time.Time
+	active      map[string]bool
+}
+
+// NewMonitor creates a new Monitor with the given thresholds
+func NewMonitor(cfg Config) *Monitor {
+	return &Monitor{Config: cfg}
+}
+
+// SetHeaters replaces the tracked heaters
+func (m *Monitor) SetHeaters(heaters []heat.Heater) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.heaters = heaters
+}
+
+// ApplyPatch merges an object model patch into the tracked heaters.
+// Parts of the patch that do not belong to the heaters are ignored.
+func (m *Monitor) ApplyPatch(patch []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	type heaters struct {
+		Heaters *[]heat.Heater `json:"heaters"`
+	}
+	p := struct {
+		Heat heaters `json:"heat"`
+	}{Heat: heaters{Heaters: &m.heaters}}
+	return json.Unmarshal(patch, &p)
+}
+
+// Check evaluates the current readings at the given time and returns new faults.
+// If EmergencyStop is set M112 is sent when there are any. Heaters that are not
+// configured or offline are skipped and sensor faults of heaters that are off are
+// ignored, but they are still checked for overshoot.
+func (m *Monitor) Check(now time.Time) ([]Fault, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.trackers) > len(m.heaters) {
+		m.trackers = m.trackers[:len(m.heaters)]
+	}
+	for len(m.trackers) < len(m.heaters) {
+		m.trackers = append(m.trackers, tracker{})
+	}
+
+	var faults []Fault
+	for i := range m.heaters {
+		faults = append(faults, m.trackers[i].check(now, i, &m.heaters[i], &m.Config)...)
+	}
+	if len(faults) > 0 && m.EmergencyStop != nil {
+		if _, err := m.EmergencyStop.PerformSimpleCode("M112", types.DefaultChannel); err != nil {
+			return faults, err
+		}
+	}
+	return faults, nil
+}
+
+// patchResult is a patch received from a subscription
+type patchResult struct {
+	patch string
+	err   error
+}
+
+// Watch receives updates from the given subscription and calls f for every new
+// fault until an error occurs. The heaters are checked in the configured interval
+// as well because a frozen sensor does not cause any patches.
+func (m *Monitor) Watch(sub connection.ModelSubscription, f func(Fault)) error {
+	mm, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	m.SetHeaters(mm.Heat.Heaters)
+
+	patches := make(chan patchResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			patch, err := sub.GetMachineModelPatch()
+			select {
+			case patches <- patchResult{patch: patch, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	interval := m.Config.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		faults, err := m.Check(time.Now())
+		for _, fault := range faults {
+			f(fault)
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case p := <-patches:
+			if p.err != nil {
+				return p.err
+			}
+			if err = m.ApplyPatch([]byte(p.patch)); err != nil {
+				return err
+			}
+		case <-t.C:
+		}
+	}
+}
+
+// monitored returns false if the heater is not configured or cannot be reached,
+// so its readings are meaningless
+func monitored(h *heat.Heater) bool {
+	return h.State != nil && *h.State != heat.Offline
+}
+
+// target returns the setpoint of the heater or nil if it is not driven
+func target(h *heat.Heater) *float64 {
+	if h.State == nil {
+		return nil
+	}
+	switch *h.State {
+	case heat.Active:
+		return &h.Active
+	case heat.Standby:
+		return &h.Standby
+	default:
+		return nil
+	}
+}
+
+func (t *tracker) check(now time.Time, index int, h *heat.Heater, cfg *Config) []Fault {
+	if !monitored(h) {
+		*t = tracker{}
+		return nil
+	}
+	cur := h.Current
+	tgt := target(h)
+	if tgt != nil {
+		v := *tgt
+		tgt = &v
+	}
+	if (tgt == nil) != (t.target == nil) || (tgt != nil && *tgt != *t.target) {
+		// Setpoint changed
+		t.target = tgt
+		t.reached = false
+		t.heating = false
+		t.deviating = time.Time{}
+	}
+	if t.lastChange.IsZero() || cur != t.lastValue {
+		t.lastValue = cur
+		t.lastChange = now
+	}
+
+	conditions := make(map[string]Fault)
+	add := func(kind FaultKind, monitor int, action *heat.HeaterMonitorAction) {
+		key := string(kind)
+		if monitor >= 0 {
+			key = fmt.Sprintf("%s/%d", kind, monitor)
+		}
+		conditions[key] = Fault{
+			Time:        now,
+			Heater:      index,
+			Name:        h.Name,
+			Kind:        kind,
+			Temperature: cur,
+			Target:      tgt,
+			Monitor:     monitor,
+			Action:      action,
+		}
+	}
+
+	if math.IsNaN(cur) || cur <= heat.AbsoluteZero+1 {
+		if *h.State != heat.Off {
+			// An unused heater may have no sensor attached
+			add(SensorFault, -1, nil)
+		}
+	} else {
+		if h.Max > 0 && cur > h.Max {
+			add(Overshoot, -1, nil)
+		}
+		if tgt != nil && math.Abs(cur-*tgt) > cfg.MaxDeviation && now.Sub(t.lastChange) >= cfg.StuckTime {
+			add(SensorStuck, -1, nil)
+		}
+		if tgt != nil {
+			t.checkSetpoint(now, h, *tgt, cfg, add)
+		}
+	}
+
+	for i, mon := range h.Monitors {
+		if mon.Limit == nil {
+			continue
+		}
+		if (mon.Condition == heat.TooHigh && cur > *mon.Limit) || (mon.Condition == heat.TooLow && cur < *mon.Limit) {
+			add(MonitorTriggered, i, mon.Action)
+		}
+	}
+
+	// Report conditions only when they start to be met
+	keys := make([]string, 0, len(conditions))
+	for key := range conditions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var faults []Fault
+	for _, key := range keys {
+		if !t.active[key] {
+			faults = append(faults, conditions[key])
+		}
+	}
+	t.active = make(map[string]bool, len(conditions))
+	for key := range conditions {
+		t.active[key] = true
+	}
+	return faults
+}
+
+// checkSetpoint checks the heating rate while heating up and the deviation
+// once the setpoint has been reached
+func (t *tracker) checkSetpoint(now time.Time, h *heat.Heater, tgt float64, cfg *Config, add func(FaultKind, int, *heat.HeaterMonitorAction)) {
+	cur := h.Current
+	if math.Abs(cur-tgt) <= cfg.MaxDeviation {
+		t.reached = true
+		t.heating = false
+		t.deviating = time.Time{}
+		return
+	}
+
+	if t.reached {
+		if t.deviating.IsZero() {
+			t.deviating = now
+		}
+		if now.Sub(t.deviating) >= cfg.DeviationTime {
+			add(Deviation, -1, nil)
+		}
+		return
+	}
+
+	if cur > tgt {
+		// Cooling down towards the setpoint
+		t.heating = false
+		return
+	}
+	if !t.heating {
+		t.heating = true
+		t.heatingFrom = now
+		t.windowTime, t.windowTemp = now, cur
+	}
+
+	model := h.Model
+	deadTime := time.Duration(model.DeadTime * float64(time.Second))
+	if model.HeatingRate <= 0 {
+		return
+	}
+	if now.Sub(t.heatingFrom) < deadTime {
+		// Measure only once the heater has started to respond
+		t.windowTime, t.windowTemp = now, cur
+		return
+	}
+	if dt := now.Sub(t.windowTime); dt >= cfg.HeatingCheckPeriod {
+		maxPwm := model.MaxPwm
+		if maxPwm <= 0 {
+			maxPwm = 1
+		}
+		expected := model.HeatingRate * maxPwm
+		if model.TimeConstant > 0 {
+			// Losses grow with the difference to the ambient temperature
+			expected -= (t.windowTemp - cfg.AmbientTemperature) / model.TimeConstant
+		}
+		rate := (cur - t.windowTemp) / dt.Seconds()
+		if expected > 0 && rate < expected*cfg.MinHeatingRateFraction {
+			add(HeatingTooSlow, -1, nil)
+			// Keep the window so that the fault remains active
+			return
+		}
+		t.windowTime, t.windowTemp = now, cur
+	}
+}
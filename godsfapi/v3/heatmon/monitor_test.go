@@ -0,0 +1,147 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatmon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+func parseHeaters(t *testing.T, data string) []heat.Heater {
+	t.Helper()
+	var heaters []heat.Heater
+	if err := json.Unmarshal([]byte(data), &heaters); err != nil {
+		t.Fatalf("Failed to parse heaters: %v", err)
+	}
+	return heaters
+}
+
+// codeRecorder records the codes sent by the monitor
+type codeRecorder struct {
+	codes []string
+}
+
+func (r *codeRecorder) PerformSimpleCode(code string, channel types.CodeChannel) (string, error) {
+	r.codes = append(r.codes, code)
+	return "", nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		heaters string
+		kinds   []FaultKind
+	}{
+		{"active heater without sensor", `[{"state":"active","active":200,"current":-273.1,"max":285}]`, []FaultKind{SensorFault}},
+		{"off heater without sensor", `[{"state":"off","current":-273.1,"max":285}]`, nil},
+		{"offline heater", `[{"state":"offline","current":-273.1,"max":285}]`, nil},
+		{"unconfigured heater", `[{"state":null,"current":-273.1,"max":285}]`, nil},
+		{"off heater above maximum", `[{"state":"off","current":300,"max":285}]`, []FaultKind{Overshoot}},
+		{"heater at setpoint", `[{"state":"active","active":200,"current":201,"max":285}]`, nil},
+		{"monitor", `[{"state":"active","active":200,"current":201,"max":285,
+			"monitors":[{"action":0,"condition":"tooHigh","limit":190}]}]`, []FaultKind{MonitorTriggered}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &codeRecorder{}
+			m := NewMonitor(DefaultConfig())
+			m.EmergencyStop = r
+			m.SetHeaters(parseHeaters(t, tt.heaters))
+			faults, err := m.Check(time.Now())
+			if err != nil {
+				t.Fatalf("Check failed: %v", err)
+			}
+			if len(faults) != len(tt.kinds) {
+				t.Fatalf("Expected faults %v but got %v", tt.kinds, faults)
+			}
+			for i, f := range faults {
+				if f.Kind != tt.kinds[i] {
+					t.Errorf("Expected fault %s but got %s", tt.kinds[i], f.Kind)
+				}
+			}
+			if (len(faults) > 0) != (len(r.codes) == 1 && r.codes[0] == "M112") {
+				t.Errorf("Unexpected emergency stop codes %v", r.codes)
+			}
+
+			// Faults are reported only once
+			if faults, _ = m.Check(time.Now()); len(faults) != 0 {
+				t.Errorf("Faults were reported again: %v", faults)
+			}
+		})
+	}
+}
+
+func TestSensorStuck(t *testing.T) {
+	cfg := DefaultConfig()
+	m := NewMonitor(cfg)
+	m.SetHeaters(parseHeaters(t, `[{"state":"active","active":200,"current":120,"max":285}]`))
+	start := time.Now()
+	if faults, _ := m.Check(start); len(faults) != 0 {
+		t.Fatalf("Unexpected faults %v", faults)
+	}
+	if faults, _ := m.Check(start.Add(cfg.StuckTime / 2)); len(faults) != 0 {
+		t.Fatalf("Unexpected faults %v", faults)
+	}
+	faults, _ := m.Check(start.Add(cfg.StuckTime))
+	if len(faults) != 1 || faults[0].Kind != SensorStuck {
+		t.Fatalf("Expected a stuck sensor but got %v", faults)
+	}
+}
+
+// frozenSubscription delivers the initial model and then no patches until it is closed
+type frozenSubscription struct {
+	model  *machine.MachineModel
+	closed chan struct{}
+}
+
+var errClosed = errors.New("Subscription closed")
+
+func (s *frozenSubscription) GetMachineModel() (*machine.MachineModel, error) {
+	return s.model, nil
+}
+
+func (s *frozenSubscription) GetMachineModelPatch() (string, error) {
+	<-s.closed
+	return "", errClosed
+}
+
+func (s *frozenSubscription) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func TestWatchWithoutPatches(t *testing.T) {
+	mm := machine.NewMachineModel()
+	mm.Heat.Heaters = parseHeaters(t, `[{"state":"active","active":200,"current":120,"max":285}]`)
+	sub := &frozenSubscription{model: mm, closed: make(chan struct{})}
+
+	cfg := DefaultConfig()
+	cfg.StuckTime = 50 * time.Millisecond
+	cfg.CheckInterval = 10 * time.Millisecond
+	m := NewMonitor(cfg)
+
+	faults := make(chan Fault, 1)
+	result := make(chan error, 1)
+	go func() {
+		result <- m.Watch(sub, func(f Fault) { faults <- f })
+	}()
+
+	select {
+	case f := <-faults:
+		if f.Kind != SensorStuck {
+			t.Errorf("Expected a stuck sensor but got %v", f)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("No fault was reported")
+	}
+
+	sub.Close()
+	if err := <-result; err != errClosed {
+		t.Errorf("Watch returned %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+/*
+Package heatsim simulates heaters using the thermal model of RepRapFirmware.
+
+The model is a first order process with dead time: at a constant PWM value u
+the temperature approaches ambient + gain * u with the time constant of the
+heater after the dead time has passed. A Simulator produces temperature
+trajectories for given PWM values or for a PID controller that drives the
+heater to a setpoint, and Fit estimates the model parameters from a recorded
+temperature and PWM trace. Times are given in seconds.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatsim
@@ -0,0 +1,137 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatsim
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/internal/linalg"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+)
+
+var (
+	// ErrTraceTooShort is returned if a trace has too few samples to fit a model
+	ErrTraceTooShort = errors.New("Trace is too short")
+	// ErrNoExcitation is returned if the PWM or temperature of a trace barely changes
+	ErrNoExcitation = errors.New("Trace does not contain enough variation")
+)
+
+// FitResult holds the estimated parameters of a heater
+type FitResult struct {
+	// Model with the estimated gain, time constant, dead time and heating rate
+	Model heat.HeaterModel `json:"model"`
+	// AmbientTemperature is the estimated ambient temperature (in degC)
+	AmbientTemperature float64 `json:"ambientTemperature"`
+	// RMSE is the root mean square error of the simulated trace (in K)
+	RMSE float64 `json:"rmse"`
+}
+
+// Fit estimates the model parameters from a recorded trace ordered by time. The
+// PWM value of each sample is assumed to be applied until the next sample and
+// dead times up to maxDeadTime (in s) are considered.
+func Fit(trace []Sample, maxDeadTime float64) (*FitResult, error) {
+	if len(trace) < 4 {
+		return nil, ErrTraceTooShort
+	}
+	intervals := make([]float64, 0, len(trace)-1)
+	maxPwm := 0.0
+	for i, s := range trace {
+		if i > 0 {
+			intervals = append(intervals, s.Time-trace[i-1].Time)
+		}
+		maxPwm = math.Max(maxPwm, s.PWM)
+	}
+	sort.Float64s(intervals)
+	step := intervals[len(intervals)/2]
+	if step <= 0 {
+		return nil, ErrTraceTooShort
+	}
+
+	var best *FitResult
+	for dead := 0.0; dead <= maxDeadTime+step/2; dead += step {
+		gain, tc, ambient, ok := fitDeadTime(trace, dead)
+		if !ok {
+			continue
+		}
+		rmse := simulationError(trace, gain, tc, dead, ambient)
+		if best == nil || rmse < best.RMSE {
+			best = &FitResult{
+				Model: heat.HeaterModel{
+					DeadTime:     dead,
+					Enabled:      true,
+					Gain:         gain,
+					HeatingRate:  gain / tc,
+					MaxPwm:       maxPwm,
+					TimeConstant: tc,
+				},
+				AmbientTemperature: ambient,
+				RMSE:               rmse,
+			}
+		}
+	}
+	if best == nil {
+		return nil, ErrNoExcitation
+	}
+	return best, nil
+}
+
+// fitDeadTime fits dT/dt = a * u(t - dead) - b * T + c using least squares
+// and converts the coefficients into gain = a/b, time constant = 1/b and
+// ambient temperature = c/b
+func fitDeadTime(trace []Sample, dead float64) (gain, tc, ambient float64, ok bool) {
+	var ata [3][3]float64
+	var atb [3]float64
+	for i := 0; i+1 < len(trace); i++ {
+		dt := trace[i+1].Time - trace[i].Time
+		if dt <= 0 {
+			continue
+		}
+		mid := trace[i].Time + dt/2
+		row := [3]float64{pwmAt(trace, mid-dead), -(trace[i].Temperature + trace[i+1].Temperature) / 2, 1}
+		y := (trace[i+1].Temperature - trace[i].Temperature) / dt
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				ata[r][c] += row[r] * row[c]
+			}
+			atb[r] += row[r] * y
+		}
+	}
+
+	x, ok := linalg.Solve3(ata, atb)
+	if !ok || x[0] <= 0 || x[1] <= 0 {
+		return 0, 0, 0, false
+	}
+	return x[0] / x[1], 1 / x[1], x[2] / x[1], true
+}
+
+// pwmAt returns the PWM value that was applied at the given time or 0 before the trace
+func pwmAt(trace []Sample, t float64) float64 {
+	i := sort.Search(len(trace), func(i int) bool { return trace[i].Time > t })
+	if i == 0 {
+		return 0
+	}
+	return trace[i-1].PWM
+}
+
+// simulationError simulates the trace with the given parameters and returns the RMSE
+func simulationError(trace []Sample, gain, tc, dead, ambient float64) float64 {
+	temp := trace[0].Temperature
+	sum := 0.0
+	for i := 0; i+1 < len(trace); i++ {
+		// Subdivide intervals at PWM changes caused by the dead time
+		t, end := trace[i].Time, trace[i+1].Time
+		for t < end {
+			next := end
+			if j := sort.Search(len(trace), func(j int) bool { return trace[j].Time > t-dead }); j < len(trace) {
+				next = math.Min(next, trace[j].Time+dead)
+			}
+			steady := ambient + gain*pwmAt(trace, t-dead)
+			temp = steady + (temp-steady)*math.Exp(-(next-t)/tc)
+			t = next
+		}
+		d := temp - trace[i+1].Temperature
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(trace)-1))
+}
@@ -0,0 +1,100 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatsim
+
+import (
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+)
+
+// Controller is a PID controller producing PWM values (0..1)
+type Controller struct {
+	// P is the proportional gain (PWM per K)
+	P float64
+	// I is the integral gain (PWM per K*s)
+	I float64
+	// D is the derivative gain (PWM per K/s)
+	D float64
+	// MaxPwm limits the output
+	MaxPwm float64
+
+	integral float64
+	last     float64
+	started  bool
+}
+
+// NewController creates a controller from the PID parameters of the given model.
+// If custom values are not in use they are calculated from the model like
+// RepRapFirmware does for setpoint changes. Custom values are expected in PWM
+// per K, so M301 values need to be divided by 255.
+func NewController(m heat.HeaterModel) *Controller {
+	c := &Controller{MaxPwm: m.MaxPwm}
+	if c.MaxPwm <= 0 {
+		c.MaxPwm = 1
+	}
+	if m.PID.Overridden {
+		c.P, c.I, c.D = m.PID.P, m.PID.I, m.PID.D
+	} else if m.Gain > 0 && m.DeadTime > 0 && m.TimeConstant > 0 {
+		kP := 0.7 / (m.Gain * math.Min(m.DeadTime/m.TimeConstant, 1))
+		c.P = kP
+		c.I = kP / math.Sqrt(m.TimeConstant*m.DeadTime)
+		c.D = kP * 0.7 * m.DeadTime
+	}
+	return c
+}
+
+// Reset clears the state of the controller
+func (c *Controller) Reset() {
+	c.integral, c.last, c.started = 0, 0, false
+}
+
+// Update calculates the PWM value for the given temperature after dt seconds
+func (c *Controller) Update(setpoint, temperature, dt float64) float64 {
+	err := setpoint - temperature
+	derivative := 0.0
+	if c.started && dt > 0 {
+		// Differentiate the measurement to avoid kicks on setpoint changes
+		derivative = -(temperature - c.last) / dt
+	}
+	c.last, c.started = temperature, true
+
+	u := c.P*err + c.I*(c.integral+err*dt) + c.D*derivative
+	if u >= 0 && u <= c.MaxPwm {
+		// Integrate only while the output is not saturated
+		c.integral += err * dt
+	}
+	return math.Max(0, math.Min(u, c.MaxPwm))
+}
+
+// Response describes how a trajectory reached a setpoint
+type Response struct {
+	// RiseTime is the time until 90% of the step was reached or -1
+	RiseTime float64 `json:"riseTime"`
+	// Overshoot is the maximum temperature above the setpoint (in K)
+	Overshoot float64 `json:"overshoot"`
+	// SettlingTime is the time after which the temperature stays within the tolerance or -1
+	SettlingTime float64 `json:"settlingTime"`
+}
+
+// Analyze determines the step response of a trajectory towards the setpoint.
+// The temperature is considered settled once it stays within tolerance (in K).
+func Analyze(samples []Sample, setpoint, tolerance float64) Response {
+	r := Response{RiseTime: -1, SettlingTime: -1}
+	if len(samples) == 0 {
+		return r
+	}
+	start := samples[0]
+	threshold := start.Temperature + 0.9*(setpoint-start.Temperature)
+	for _, s := range samples {
+		if r.RiseTime < 0 && s.Temperature >= threshold {
+			r.RiseTime = s.Time - start.Time
+		}
+		r.Overshoot = math.Max(r.Overshoot, s.Temperature-setpoint)
+		if math.Abs(s.Temperature-setpoint) > tolerance {
+			r.SettlingTime = -1
+		} else if r.SettlingTime < 0 {
+			r.SettlingTime = s.Time - start.Time
+		}
+	}
+	return r
+}
@@ -0,0 +1,115 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heatsim
+
+import (
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+)
+
+const (
+	// DefaultInterval is the default simulation step (in s) which matches the
+	// heater sampling interval of RepRapFirmware
+	DefaultInterval = 0.25
+	// DefaultAmbientTemperature in degC
+	DefaultAmbientTemperature = 25.0
+)
+
+// Sample is a single point of a temperature trajectory
+type Sample struct {
+	// Time since the start (in s)
+	Time float64 `json:"time"`
+	// PWM value applied at this time (0..1)
+	PWM float64 `json:"pwm"`
+	// Temperature at this time (in degC)
+	Temperature float64 `json:"temperature"`
+}
+
+// Simulator calculates the temperature of a single heater
+type Simulator struct {
+	// Model of the heater
+	Model heat.HeaterModel
+	// AmbientTemperature in degC
+	AmbientTemperature float64
+	// FanOn selects the time constant with the fan on if the model has one
+	FanOn bool
+	// Interval is the simulation step (in s)
+	Interval float64
+	// Time since the start (in s)
+	Time float64
+	// Temperature of the heater (in degC)
+	Temperature float64
+
+	// pending holds the PWM values that are delayed by the dead time
+	pending []float64
+}
+
+// NewSimulator creates a new Simulator for the given model starting at ambient temperature
+func NewSimulator(m heat.HeaterModel, ambient float64) *Simulator {
+	return &Simulator{
+		Model:              m,
+		AmbientTemperature: ambient,
+		Interval:           DefaultInterval,
+		Temperature:        ambient,
+	}
+}
+
+// timeConstant returns the time constant that applies
+func (s *Simulator) timeConstant() float64 {
+	if s.FanOn && s.Model.TimeConstantFanOn > 0 {
+		return s.Model.TimeConstantFanOn
+	}
+	return s.Model.TimeConstant
+}
+
+// Step applies the given PWM value for one interval and returns the new temperature.
+// The PWM value is limited to the maximum PWM of the model.
+func (s *Simulator) Step(pwm float64) float64 {
+	maxPwm := s.Model.MaxPwm
+	if maxPwm <= 0 {
+		maxPwm = 1
+	}
+	pwm = math.Max(0, math.Min(pwm, maxPwm))
+
+	// Delay the input by the dead time
+	delay := int(math.Round(s.Model.DeadTime / s.Interval))
+	s.pending = append(s.pending, pwm)
+	effective := 0.0
+	if len(s.pending) > delay {
+		effective = s.pending[0]
+		s.pending = s.pending[1:]
+	}
+
+	// Exact solution for a constant input during the interval
+	steady := s.AmbientTemperature + s.Model.Gain*effective
+	if tc := s.timeConstant(); tc > 0 {
+		s.Temperature = steady + (s.Temperature-steady)*math.Exp(-s.Interval/tc)
+	} else {
+		s.Temperature = steady
+	}
+	s.Time += s.Interval
+	return s.Temperature
+}
+
+// Run applies the given PWM values one interval each and returns the trajectory
+// starting with the current state
+func (s *Simulator) Run(pwm []float64) []Sample {
+	samples := make([]Sample, 0, len(pwm)+1)
+	for _, u := range pwm {
+		samples = append(samples, Sample{Time: s.Time, PWM: u, Temperature: s.Temperature})
+		s.Step(u)
+	}
+	return append(samples, Sample{Time: s.Time, Temperature: s.Temperature})
+}
+
+// RunPID drives the heater to the setpoint using the given controller for the
+// given duration (in s) and returns the trajectory
+func (s *Simulator) RunPID(c *Controller, setpoint, duration float64) []Sample {
+	var samples []Sample
+	for end := s.Time + duration; s.Time < end; {
+		u := c.Update(setpoint, s.Temperature, s.Interval)
+		samples = append(samples, Sample{Time: s.Time, PWM: u, Temperature: s.Temperature})
+		s.Step(u)
+	}
+	return append(samples, Sample{Time: s.Time, Temperature: s.Temperature})
+}
@@ -0,0 +1,169 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heightmap
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/internal/linalg"
+)
+
+// Stats holds statistics about the probed points of a height map
+type Stats struct {
+	// Points is the number of probed points
+	Points int `json:"points"`
+	// Min is the lowest height error (in mm)
+	Min float64 `json:"min"`
+	// Max is the highest height error (in mm)
+	Max float64 `json:"max"`
+	// Range between the lowest and highest height error (in mm)
+	Range float64 `json:"range"`
+	// Mean height error (in mm)
+	Mean float64 `json:"mean"`
+	// Deviation is the standard deviation of the height errors (in mm) like
+	// MoveCompensation.MeshDeviation
+	Deviation float64 `json:"deviation"`
+	// Plane is the best fitting plane
+	Plane Plane `json:"plane"`
+}
+
+// Plane is given by Z = SlopeX * X + SlopeY * Y + Offset
+type Plane struct {
+	// SlopeX is the height change per mm along the first axis
+	SlopeX float64 `json:"slopeX"`
+	// SlopeY is the height change per mm along the second axis
+	SlopeY float64 `json:"slopeY"`
+	// Offset is the height at the origin (in mm)
+	Offset float64 `json:"offset"`
+}
+
+// Z returns the height of the plane at the given position
+func (p Plane) Z(x, y float64) float64 {
+	return p.SlopeX*x + p.SlopeY*y + p.Offset
+}
+
+// Tilt returns the maximum inclination of the plane (in degrees)
+func (p Plane) Tilt() float64 {
+	return math.Atan(math.Hypot(p.SlopeX, p.SlopeY)) * 180 / math.Pi
+}
+
+// Analyze calculates statistics of the probed points
+func (hm *HeightMap) Analyze() Stats {
+	var s Stats
+	var sum, sumSq float64
+	var ata [3][3]float64
+	var atb [3]float64
+	for row := range hm.Z {
+		for col, z := range hm.Z[row] {
+			if math.IsNaN(z) {
+				continue
+			}
+			if s.Points == 0 || z < s.Min {
+				s.Min = z
+			}
+			if s.Points == 0 || z > s.Max {
+				s.Max = z
+			}
+			s.Points++
+			sum += z
+			sumSq += z * z
+
+			x, y := hm.Coordinates(col, row)
+			v := [3]float64{x, y, 1}
+			for i := range v {
+				for j := range v {
+					ata[i][j] += v[i] * v[j]
+				}
+				atb[i] += v[i] * z
+			}
+		}
+	}
+	if s.Points == 0 {
+		return s
+	}
+
+	n := float64(s.Points)
+	s.Range = s.Max - s.Min
+	s.Mean = sum / n
+	s.Deviation = math.Sqrt(math.Max(0, sumSq/n-s.Mean*s.Mean))
+	if p, ok := linalg.Solve3(ata, atb); ok {
+		s.Plane = Plane{SlopeX: p[0], SlopeY: p[1], Offset: p[2]}
+	} else {
+		// Too few points for a plane
+		s.Plane.Offset = s.Mean
+	}
+	return s
+}
+
+// Interpolate returns the height error at the given position like the firmware
+// does. Positions outside the grid use the height errors at its edge. Unprobed
+// grid points are left out, so positions at an unprobed point use the average of
+// the probed corners of the grid cell. False is returned if none of them was probed.
+func (hm *HeightMap) Interpolate(x, y float64) (float64, bool) {
+	xf := clamp((x-hm.Mins[0])/hm.Spacings[0], float64(hm.Points[0]-1))
+	yf := clamp((y-hm.Mins[1])/hm.Spacings[1], float64(hm.Points[1]-1))
+	col, row := int(math.Floor(xf)), int(math.Floor(yf))
+	fx, fy := xf-float64(col), yf-float64(row)
+
+	var sum, weights, fallback float64
+	var probed int
+	corners := [4]struct {
+		col, row int
+		weight   float64
+	}{
+		{col, row, (1 - fx) * (1 - fy)},
+		{col + 1, row, fx * (1 - fy)},
+		{col, row + 1, (1 - fx) * fy},
+		{col + 1, row + 1, fx * fy},
+	}
+	for _, c := range corners {
+		if z, ok := hm.Get(c.col, c.row); ok {
+			sum += z * c.weight
+			weights += c.weight
+			fallback += z
+			probed++
+		}
+	}
+	if weights > 0 {
+		return sum / weights, true
+	}
+	if probed > 0 {
+		return fallback / float64(probed), true
+	}
+	return 0, false
+}
+
+func clamp(f, max float64) float64 {
+	return math.Max(0, math.Min(f, max))
+}
+
+// Compare returns a height map holding the differences other - hm at the grid
+// points of hm. If the grids differ the height errors of other are interpolated.
+func (hm *HeightMap) Compare(other *HeightMap) (*HeightMap, error) {
+	if hm.Axes != other.Axes {
+		return nil, errors.New("Height maps use different axes")
+	}
+	result := *hm
+	result.Generated = ""
+	result.Header = make(map[string]float64)
+	result.Z = make([][]float64, len(hm.Z))
+	same := hm.SameGrid(other)
+	for row := range hm.Z {
+		result.Z[row] = make([]float64, len(hm.Z[row]))
+		for col, z := range hm.Z[row] {
+			var o float64
+			var ok bool
+			if same {
+				o, ok = other.Get(col, row)
+			} else {
+				o, ok = other.Interpolate(hm.Coordinates(col, row))
+			}
+			if !ok || math.IsNaN(z) {
+				result.Z[row][col] = math.NaN()
+			} else {
+				result.Z[row][col] = o - z
+			}
+		}
+	}
+	return &result, nil
+}
@@ -0,0 +1,12 @@
+/*
+Package heightmap reads and analyses the height maps of mesh bed compensation.
+
+RepRapFirmware stores the result of G29 in a CSV file (usually
+0:/sys/heightmap.csv) that is referenced by MoveCompensation.File. This package
+parses both versions of the file format including unprobed points and grids of
+circular beds, calculates statistics and the tilt of the bed, interpolates the
+height error at arbitrary positions like the firmware, compares two height maps
+and renders them as PNG or SVG images.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heightmap
@@ -0,0 +1,222 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heightmap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+// headerPrefix is the start of the first line of every height map file
+const headerPrefix = "RepRapFirmware height map file v"
+
+// ErrInvalidFormat is returned if a file is not a valid height map
+var ErrInvalidFormat = errors.New("Invalid height map file")
+
+// HeightMap is a grid of measured height errors
+type HeightMap struct {
+	// Version of the file format
+	Version int
+	// Generated is the time the file was generated as written by the firmware
+	Generated string
+	// Header holds the numeric values of the first line like "mean" or "deviation"
+	Header map[string]float64
+	// Axes are the letters of the two grid axes
+	Axes [2]string
+	// Mins are the start coordinates of the grid
+	Mins [2]float64
+	// Maxs are the end coordinates of the grid
+	Maxs [2]float64
+	// Radius of the probed area on circular beds or a value <= 0 otherwise
+	Radius float64
+	// Spacings between two points of the grid
+	Spacings [2]float64
+	// Points is the number of points along each axis
+	Points [2]int
+	// Z holds the height errors by row (second axis) and column (first axis).
+	// Points that were not probed are NaN.
+	Z [][]float64
+}
+
+// PathResolver converts firmware paths into real paths, e.g. a connection.CommandConnection
+type PathResolver interface {
+	ResolvePath(path string) (string, error)
+}
+
+// LocalPath converts a firmware path like 0:/sys/heightmap.csv into a path
+// below root, e.g. a copy of the SD card
+func LocalPath(file, root string) string {
+	if i := strings.Index(file, ":/"); i >= 0 {
+		file = file[i+2:]
+	}
+	return filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(file, "/")))
+}
+
+// LoadCompensation loads the height map that is currently in use
+func LoadCompensation(r PathResolver, mc move.MoveCompensation) (*HeightMap, error) {
+	if mc.File == "" {
+		return nil, errors.New("No height map is in use")
+	}
+	path, err := r.ResolvePath(mc.File)
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Load reads a height map file
+func Load(path string) (*HeightMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads a height map in the format of RepRapFirmware
+func Parse(r io.Reader) (*HeightMap, error) {
+	s := bufio.NewScanner(r)
+	var lines []string
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) < 3 || !strings.HasPrefix(lines[0], headerPrefix) {
+		return nil, ErrInvalidFormat
+	}
+
+	hm := &HeightMap{Axes: [2]string{"X", "Y"}, Header: make(map[string]float64)}
+	hm.parseHeader(lines[0])
+	if err := hm.parseGrid(lines[1], lines[2]); err != nil {
+		return nil, err
+	}
+
+	rows := lines[3:]
+	if len(rows) < hm.Points[1] {
+		return nil, fmt.Errorf("%w: expected %d rows but got %d", ErrInvalidFormat, hm.Points[1], len(rows))
+	}
+	hm.Z = make([][]float64, hm.Points[1])
+	for y := range hm.Z {
+		values := strings.Split(rows[y], ",")
+		if len(values) < hm.Points[0] {
+			return nil, fmt.Errorf("%w: row %d has %d values instead of %d", ErrInvalidFormat, y, len(values), hm.Points[0])
+		}
+		hm.Z[y] = make([]float64, hm.Points[0])
+		for x := range hm.Z[y] {
+			v := strings.TrimSpace(values[x])
+			if !strings.Contains(v, ".") {
+				// Unprobed points are written as 0 without decimal places
+				hm.Z[y][x] = math.NaN()
+				continue
+			}
+			z, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, err)
+			}
+			hm.Z[y][x] = z
+		}
+	}
+	return hm, nil
+}
+
+// parseHeader parses a line like "RepRapFirmware height map file v2 generated at
+// 2020-06-02 17:15, min error -0.123, max error 0.456, mean 0.012, deviation 0.070"
+func (hm *HeightMap) parseHeader(line string) {
+	parts := strings.Split(line[len(headerPrefix):], ",")
+	first := strings.Fields(parts[0])
+	if len(first) > 0 {
+		hm.Version, _ = strconv.Atoi(first[0])
+	}
+	if i := strings.Index(parts[0], "generated at"); i >= 0 {
+		hm.Generated = strings.TrimSpace(parts[0][i+len("generated at"):])
+	}
+	for _, p := range parts[1:] {
+		p = strings.TrimSpace(p)
+		i := strings.LastIndex(p, " ")
+		if i < 0 {
+			continue
+		}
+		if v, err := strconv.ParseFloat(p[i+1:], 64); err == nil {
+			hm.Header[strings.TrimSpace(p[:i])] = v
+		}
+	}
+}
+
+// parseGrid parses the grid definition given by a line of names and a line of values
+func (hm *HeightMap) parseGrid(names, values string) error {
+	n, v := strings.Split(names, ","), strings.Split(values, ",")
+	if len(n) != len(v) {
+		return fmt.Errorf("%w: grid definition mismatch", ErrInvalidFormat)
+	}
+	for i := range n {
+		name, value := strings.ToLower(strings.TrimSpace(n[i])), strings.TrimSpace(v[i])
+		switch name {
+		case "axis0":
+			hm.Axes[0] = value
+			continue
+		case "axis1":
+			hm.Axes[1] = value
+			continue
+		}
+
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidFormat, err)
+		}
+		switch name {
+		case "min0", "xmin":
+			hm.Mins[0] = f
+		case "max0", "xmax":
+			hm.Maxs[0] = f
+		case "min1", "ymin":
+			hm.Mins[1] = f
+		case "max1", "ymax":
+			hm.Maxs[1] = f
+		case "radius":
+			hm.Radius = f
+		case "spacing0", "xspacing":
+			hm.Spacings[0] = f
+		case "spacing1", "yspacing":
+			hm.Spacings[1] = f
+		case "num0", "xnum":
+			hm.Points[0] = int(f)
+		case "num1", "ynum":
+			hm.Points[1] = int(f)
+		}
+	}
+	if hm.Points[0] < 1 || hm.Points[1] < 1 || hm.Spacings[0] <= 0 || hm.Spacings[1] <= 0 {
+		return fmt.Errorf("%w: invalid grid", ErrInvalidFormat)
+	}
+	return nil
+}
+
+// Coordinates returns the position of the given grid point
+func (hm *HeightMap) Coordinates(col, row int) (float64, float64) {
+	return hm.Mins[0] + float64(col)*hm.Spacings[0], hm.Mins[1] + float64(row)*hm.Spacings[1]
+}
+
+// Get returns the height error of the given grid point and whether it was probed
+func (hm *HeightMap) Get(col, row int) (float64, bool) {
+	if row < 0 || row >= len(hm.Z) || col < 0 || col >= len(hm.Z[row]) || math.IsNaN(hm.Z[row][col]) {
+		return 0, false
+	}
+	return hm.Z[row][col], true
+}
+
+// SameGrid returns true if both height maps have the same grid points
+func (hm *HeightMap) SameGrid(other *HeightMap) bool {
+	return hm.Mins == other.Mins && hm.Spacings == other.Spacings && hm.Points == other.Points
+}
@@ -0,0 +1,89 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heightmap
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	hm, err := Load("testdata/heightmap.csv")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if hm.Version != 2 || hm.Generated != "2020-06-02 17:15" {
+		t.Errorf("Unexpected version %d generated at %q", hm.Version, hm.Generated)
+	}
+	if hm.Header["min error"] != -0.1 || hm.Header["max error"] != 0.12 {
+		t.Errorf("Unexpected header %v", hm.Header)
+	}
+	if hm.Mins != [2]float64{-50, -50} || hm.Maxs != [2]float64{50, 50} || hm.Spacings != [2]float64{25, 25} ||
+		hm.Points != [2]int{5, 5} || hm.Radius != 50 {
+		t.Errorf("Unexpected grid %v..%v spacing %v points %v radius %g", hm.Mins, hm.Maxs, hm.Spacings, hm.Points, hm.Radius)
+	}
+
+	tests := []struct {
+		col, row int
+		z        float64
+		probed   bool
+	}{
+		{0, 0, 0, false},
+		{2, 0, 0.05, true},
+		{1, 1, -0.025, true},
+		// A probed point with zero height error has decimal places
+		{2, 1, 0, true},
+		{4, 1, 0, false},
+		{0, 2, -0.1, true},
+		{4, 2, 0.12, true},
+		{5, 2, 0, false},
+	}
+	for _, tt := range tests {
+		z, ok := hm.Get(tt.col, tt.row)
+		if ok != tt.probed || z != tt.z {
+			t.Errorf("Get(%d, %d) = %g, %t, expected %g, %t", tt.col, tt.row, z, ok, tt.z, tt.probed)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	hm, err := Load("testdata/heightmap.csv")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	s := hm.Analyze()
+	if s.Points != 13 {
+		t.Errorf("Expected 13 probed points but got %d", s.Points)
+	}
+	if s.Min != hm.Header["min error"] || s.Max != hm.Header["max error"] {
+		t.Errorf("Min %g and max %g do not match the header", s.Min, s.Max)
+	}
+	if math.Abs(s.Mean-hm.Header["mean"]) > 0.0005 || math.Abs(s.Deviation-hm.Header["deviation"]) > 0.0005 {
+		t.Errorf("Mean %g and deviation %g do not match the header", s.Mean, s.Deviation)
+	}
+	if s.Plane.SlopeX <= 0 {
+		t.Errorf("Expected the plane to rise along X but got %+v", s.Plane)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	hm, err := Load("testdata/heightmap.csv")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	tests := []struct {
+		x, y float64
+		z    float64
+	}{
+		{0, 0, 0.01},
+		{12.5, 0, 0.035},
+		{100, 0, 0.12},
+		// Unprobed corner of the grid uses the probed corners of its cell
+		{-50, -50, -0.025},
+	}
+	for _, tt := range tests {
+		z, ok := hm.Interpolate(tt.x, tt.y)
+		if !ok || math.Abs(z-tt.z) > 1e-9 {
+			t.Errorf("Interpolate(%g, %g) = %g, %t, expected %g", tt.x, tt.y, z, ok, tt.z)
+		}
+	}
+}
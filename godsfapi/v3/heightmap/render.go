@@ -0,0 +1,123 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package heightmap
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// DefaultCellSize is the default size of a grid point (in pixels)
+const DefaultCellSize = 24
+
+// missingColor is used for unprobed points
+var missingColor = color.RGBA{R: 0xc0, G: 0xc0, B: 0xc0, A: 0xff}
+
+// RenderOptions control how a height map is rendered
+type RenderOptions struct {
+	// CellSize is the size of a grid point (in pixels)
+	CellSize int
+	// Min is the height error shown in blue or nil to use the lowest one
+	Min *float64
+	// Max is the height error shown in red or nil to use the highest one
+	Max *float64
+}
+
+// scale returns the cell size and the range of the color scale
+func (o RenderOptions) scale(hm *HeightMap) (int, float64, float64) {
+	size := o.CellSize
+	if size <= 0 {
+		size = DefaultCellSize
+	}
+	s := hm.Analyze()
+	min, max := s.Min, s.Max
+	if o.Min != nil {
+		min = *o.Min
+	}
+	if o.Max != nil {
+		max = *o.Max
+	}
+	return size, min, max
+}
+
+// colorFor maps a height error to a color from blue (min) over green to red (max)
+func colorFor(z, min, max float64) color.RGBA {
+	if math.IsNaN(z) {
+		return missingColor
+	}
+	t := 0.5
+	if max > min {
+		t = math.Max(0, math.Min(1, (z-min)/(max-min)))
+	}
+	if t < 0.5 {
+		g := uint8(math.Round(t * 2 * 255))
+		return color.RGBA{G: g, B: 255 - g, A: 0xff}
+	}
+	r := uint8(math.Round((t - 0.5) * 2 * 255))
+	return color.RGBA{R: r, G: 255 - r, A: 0xff}
+}
+
+// RenderPNG draws the grid with the first axis pointing right and the second one
+// pointing up followed by a color bar
+func (hm *HeightMap) RenderPNG(w io.Writer, opts RenderOptions) error {
+	size, min, max := opts.scale(hm)
+	width, height := hm.Points[0]*size, hm.Points[1]*size
+	img := image.NewRGBA(image.Rect(0, 0, width+2*size, height))
+
+	for row := range hm.Z {
+		for col, z := range hm.Z[row] {
+			c := colorFor(z, min, max)
+			top := (hm.Points[1] - 1 - row) * size
+			for py := top; py < top+size; py++ {
+				for px := col * size; px < (col+1)*size; px++ {
+					img.SetRGBA(px, py, c)
+				}
+			}
+		}
+	}
+	for py := 0; py < height; py++ {
+		c := colorFor(max-(max-min)*float64(py)/float64(height-1), min, max)
+		for px := width + size; px < width+2*size; px++ {
+			img.SetRGBA(px, py, c)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// RenderSVG draws the grid like RenderPNG. Every point shows its position and
+// height error as tooltip and the color bar is labelled with its range.
+func (hm *HeightMap) RenderSVG(w io.Writer, opts RenderOptions) error {
+	size, min, max := opts.scale(hm)
+	width, height := hm.Points[0]*size, hm.Points[1]*size
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d">`+"\n", width+5*size, height)
+
+	for row := range hm.Z {
+		for col, z := range hm.Z[row] {
+			c := colorFor(z, min, max)
+			x, y := hm.Coordinates(col, row)
+			value := "not probed"
+			if !math.IsNaN(z) {
+				value = fmt.Sprintf("%.3f", z)
+			}
+			fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"><title>%s%g %s%g: %s</title></rect>`+"\n",
+				col*size, (hm.Points[1]-1-row)*size, size, size, c.R, c.G, c.B, hm.Axes[0], x, hm.Axes[1], y, value)
+		}
+	}
+
+	fmt.Fprintf(bw, `<defs><linearGradient id="scale" x1="0" y1="1" x2="0" y2="0">`)
+	for _, t := range []float64{0, 0.5, 1} {
+		c := colorFor(min+(max-min)*t, min, max)
+		fmt.Fprintf(bw, `<stop offset="%g" stop-color="#%02x%02x%02x"/>`, t, c.R, c.G, c.B)
+	}
+	fmt.Fprintf(bw, "</linearGradient></defs>\n")
+	fmt.Fprintf(bw, `<rect x="%d" y="0" width="%d" height="%d" fill="url(#scale)"/>`+"\n", width+size, size, height)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-size="%d">%.3f</text>`+"\n", width+2*size+4, size/2, size/2, max)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-size="%d">%.3f</text>`+"\n", width+2*size+4, height, size/2, min)
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
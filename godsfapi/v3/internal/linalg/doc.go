@@ -0,0 +1,5 @@
+/*
+Package linalg solves the small linear systems the analysis packages need.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package linalg
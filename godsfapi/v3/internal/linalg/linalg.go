@@ -0,0 +1,106 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package linalg
+
+import "math"
+
+// epsilon is the smallest pivot that is not considered zero
+const epsilon = 1e-12
+
+// Solve solves the linear system a*x = b and returns false if a is singular
+func Solve(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(a)
+	if len(b) != n {
+		return nil, false
+	}
+	aug, ok := augment(a, 1)
+	if !ok {
+		return nil, false
+	}
+	for i := range b {
+		aug[i][n] = b[i]
+	}
+	if !eliminate(aug) {
+		return nil, false
+	}
+	x := make([]float64, n)
+	for i := range aug {
+		x[i] = aug[i][n]
+	}
+	return x, true
+}
+
+// Solve3 solves the 3x3 linear system a*x = b and returns false if a is singular
+func Solve3(a [3][3]float64, b [3]float64) ([3]float64, bool) {
+	var x [3]float64
+	r, ok := Solve([][]float64{a[0][:], a[1][:], a[2][:]}, b[:])
+	if ok {
+		copy(x[:], r)
+	}
+	return x, ok
+}
+
+// Invert returns the inverse of the square matrix m and false if m is singular
+func Invert(m [][]float64) ([][]float64, bool) {
+	n := len(m)
+	aug, ok := augment(m, n)
+	if !ok {
+		return nil, false
+	}
+	for i := range aug {
+		aug[i][n+i] = 1
+	}
+	if !eliminate(aug) {
+		return nil, false
+	}
+	result := make([][]float64, n)
+	for i := range aug {
+		result[i] = aug[i][n:]
+	}
+	return result, true
+}
+
+// augment copies the square matrix m into rows with extra zero columns
+func augment(m [][]float64, extra int) ([][]float64, bool) {
+	n := len(m)
+	a := make([][]float64, n)
+	for i, row := range m {
+		if len(row) != n {
+			return nil, false
+		}
+		a[i] = make([]float64, n+extra)
+		copy(a[i], row)
+	}
+	return a, true
+}
+
+// eliminate reduces the left square part of an augmented matrix to the identity
+// using Gauss-Jordan elimination with partial pivoting
+func eliminate(a [][]float64) bool {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < epsilon {
+			return false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		p := a[col][col]
+		for j := range a[col] {
+			a[col][j] /= p
+		}
+		for r := 0; r < n; r++ {
+			if r != col && a[r][col] != 0 {
+				f := a[r][col]
+				for j := range a[r] {
+					a[r][j] -= f * a[col][j]
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import (
+	"errors"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/internal/linalg"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+// ErrSingularMatrix is returned if a movement matrix cannot be inverted
+var ErrSingularMatrix = errors.New("Movement matrix is singular")
+
+// standardMatrices holds the inverse matrices of core kinematics that older
+// firmware versions do not report
+var standardMatrices = map[move.KinematicsName][][]float64{
+	move.CoreXY: {
+		{1, 1, 0},
+		{1, -1, 0},
+		{0, 0, 1},
+	},
+	move.CoreXZ: {
+		{1, 0, 1},
+		{0, 1, 0},
+		{1, 0, -1},
+	},
+}
+
+// Core transforms cartesian and core kinematics using movement matrices
+type Core struct {
+	// ForwardMatrix maps motor positions to coordinates
+	ForwardMatrix [][]float64
+	// InverseMatrix maps coordinates to motor positions
+	InverseMatrix [][]float64
+}
+
+// NewCore creates a transform from the movement matrices of the given kinematics.
+// If only one matrix differs from the identity the other one is calculated from it.
+// If both are the identity the standard matrices of CoreXY and CoreXZ are used.
+func NewCore(ck *move.CoreKinematics) (*Core, error) {
+	forward, inverse := ck.ForwardMatrix, ck.InverseMatrix
+	if isIdentity(forward) && isIdentity(inverse) {
+		if m, ok := standardMatrices[ck.Name]; ok {
+			forward, inverse = nil, m
+		}
+	}
+
+	var err error
+	switch {
+	case len(inverse) == 0 && len(forward) == 0:
+		forward, inverse = move.DefaultForwardMatrix(), move.DefaultInverseMatrix()
+	case len(inverse) == 0 || (isIdentity(inverse) && !isIdentity(forward)):
+		inverse, err = invert(forward)
+	case len(forward) == 0 || (isIdentity(forward) && !isIdentity(inverse)):
+		forward, err = invert(inverse)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Core{ForwardMatrix: forward, InverseMatrix: inverse}, nil
+}
+
+// Forward multiplies the motor positions with the forward matrix
+func (c *Core) Forward(motors []float64) ([]float64, error) {
+	return multiply(c.ForwardMatrix, motors)
+}
+
+// Inverse multiplies the coordinates with the inverse matrix
+func (c *Core) Inverse(coords []float64) ([]float64, error) {
+	return multiply(c.InverseMatrix, coords)
+}
+
+// multiply applies a square matrix to the first values of v.
+// Values beyond the size of the matrix are passed through.
+func multiply(m [][]float64, v []float64) ([]float64, error) {
+	if len(v) < len(m) {
+		return nil, ErrTooFewAxes
+	}
+	result := make([]float64, len(m), len(v))
+	for i, row := range m {
+		for j, f := range row {
+			if j < len(v) {
+				result[i] += f * v[j]
+			}
+		}
+	}
+	return passThrough(result, v, len(m)), nil
+}
+
+func isIdentity(m [][]float64) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for i, row := range m {
+		if len(row) != len(m) {
+			return false
+		}
+		for j, f := range row {
+			if (i == j && f != 1) || (i != j && f != 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// invert calculates the inverse of a square movement matrix
+func invert(m [][]float64) ([][]float64, error) {
+	result, ok := linalg.Invert(m)
+	if !ok {
+		return nil, ErrSingularMatrix
+	}
+	return result, nil
+}
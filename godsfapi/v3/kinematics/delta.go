@@ -0,0 +1,115 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+// Delta transforms linear delta kinematics. Motor positions are the heights of
+// the carriages above the bed plane.
+type Delta struct {
+	// TowerX are the X coordinates of the towers
+	TowerX [3]float64
+	// TowerY are the Y coordinates of the towers
+	TowerY [3]float64
+	// Diagonals are the diagonal rod lengths
+	Diagonals [3]float64
+	// HomedCarriageHeights are the carriage heights at the endstops.
+	// They are zero if the homed height is unknown.
+	HomedCarriageHeights [3]float64
+	// PrintRadius limits the reachable positions unless it is zero
+	PrintRadius float64
+	// XTilt is how much Z is raised for each unit of movement in +X direction
+	XTilt float64
+	// YTilt is how much Z is raised for each unit of movement in +Y direction
+	YTilt float64
+}
+
+// NewDelta creates a transform from the given delta kinematics. The tower
+// positions are calculated from the delta radius and angle corrections unless
+// they are reported by the object model.
+func NewDelta(dk *move.DeltaKinematics) (*Delta, error) {
+	if len(dk.Towers) < 3 {
+		return nil, errors.New("Delta kinematics require three towers")
+	}
+	d := &Delta{PrintRadius: dk.PrintRadius, XTilt: dk.XTilt, YTilt: dk.YTilt}
+
+	reported := false
+	for i := 0; i < 3; i++ {
+		if dk.Towers[i].XPos != 0 || dk.Towers[i].YPos != 0 {
+			reported = true
+		}
+		d.Diagonals[i] = dk.Towers[i].Diagonal
+	}
+	if reported {
+		for i := 0; i < 3; i++ {
+			d.TowerX[i], d.TowerY[i] = dk.Towers[i].XPos, dk.Towers[i].YPos
+		}
+	} else {
+		r := dk.DeltaRadius
+		a := (30 + dk.Towers[0].AngleCorrection) * math.Pi / 180
+		b := (30 - dk.Towers[1].AngleCorrection) * math.Pi / 180
+		c := dk.Towers[2].AngleCorrection * math.Pi / 180
+		d.TowerX = [3]float64{-r * math.Cos(a), r * math.Cos(b), -r * math.Sin(c)}
+		d.TowerY = [3]float64{-r * math.Sin(a), -r * math.Sin(b), r * math.Cos(c)}
+	}
+
+	if dk.HomedHeight != 0 {
+		for i := 0; i < 3; i++ {
+			s := d.Diagonals[i]*d.Diagonals[i] - d.TowerX[i]*d.TowerX[i] - d.TowerY[i]*d.TowerY[i]
+			if s < 0 {
+				return nil, errors.New("Delta diagonals are shorter than the tower distance")
+			}
+			d.HomedCarriageHeights[i] = dk.HomedHeight + math.Sqrt(s) + dk.Towers[i].EndstopAdjustment
+		}
+	}
+	return d, nil
+}
+
+// Forward calculates the effector position from the carriage heights
+func (d *Delta) Forward(motors []float64) ([]float64, error) {
+	if len(motors) < 3 {
+		return nil, ErrTooFewAxes
+	}
+	var centers [3]vector
+	for i := range centers {
+		centers[i] = vector{d.TowerX[i], d.TowerY[i], motors[i]}
+	}
+	p, q, err := trilaterate(centers, d.Diagonals)
+	if err != nil {
+		return nil, err
+	}
+	if q[2] < p[2] {
+		// The effector hangs below the carriages
+		p = q
+	}
+	z := p[2] - p[0]*d.XTilt - p[1]*d.YTilt
+	return passThrough([]float64{p[0], p[1], z}, motors, 3), nil
+}
+
+// Inverse calculates the carriage heights of the given effector position
+func (d *Delta) Inverse(coords []float64) ([]float64, error) {
+	if len(coords) < 3 {
+		return nil, ErrTooFewAxes
+	}
+	x, y, z := coords[0], coords[1], coords[2]
+	if d.PrintRadius > 0 && x*x+y*y > d.PrintRadius*d.PrintRadius*(1+epsilon) {
+		return nil, ErrUnreachable
+	}
+
+	motors := make([]float64, 3, len(coords))
+	for i := range motors {
+		s := d.Diagonals[i]*d.Diagonals[i] - (x-d.TowerX[i])*(x-d.TowerX[i]) - (y-d.TowerY[i])*(y-d.TowerY[i])
+		if s < 0 {
+			return nil, ErrUnreachable
+		}
+		motors[i] = math.Sqrt(s) + z + x*d.XTilt + y*d.YTilt
+		if d.HomedCarriageHeights[i] != 0 && motors[i] > d.HomedCarriageHeights[i]+epsilon {
+			return nil, ErrUnreachable
+		}
+	}
+	return passThrough(motors, coords, 3), nil
+}
@@ -0,0 +1,12 @@
+/*
+Package kinematics converts between motor positions and cartesian coordinates.
+
+Transforms are created from the kinematics reported in the object model and
+support cartesian and core kinematics using their movement matrices, linear
+delta printers, Hangprinters and SCARA arms. Motor positions are given in mm of
+carriage travel or line length and in degrees for rotating arms. Coordinates are
+ordered like the axes of the object model, i.e. X, Y, Z followed by any further
+axes which are passed through unchanged unless a matrix says otherwise.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
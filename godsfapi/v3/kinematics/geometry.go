@@ -0,0 +1,50 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import "math"
+
+// epsilon is the tolerance for rounding errors
+const epsilon = 1e-9
+
+// vector is a point or direction in space
+type vector [3]float64
+
+func (a vector) add(b vector) vector    { return vector{a[0] + b[0], a[1] + b[1], a[2] + b[2]} }
+func (a vector) sub(b vector) vector    { return vector{a[0] - b[0], a[1] - b[1], a[2] - b[2]} }
+func (a vector) scale(f float64) vector { return vector{a[0] * f, a[1] * f, a[2] * f} }
+func (a vector) dot(b vector) float64   { return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] }
+func (a vector) length() float64        { return math.Sqrt(a.dot(a)) }
+
+func (a vector) cross(b vector) vector {
+	return vector{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+// trilaterate returns both intersections of three spheres. The first one is
+// on the side of the plane through the centers that the normal c1-c0 x c2-c0
+// points away from.
+func trilaterate(c [3]vector, r [3]float64) (vector, vector, error) {
+	d := c[1].sub(c[0]).length()
+	if d < epsilon {
+		return vector{}, vector{}, ErrUnreachable
+	}
+	ex := c[1].sub(c[0]).scale(1 / d)
+	i := ex.dot(c[2].sub(c[0]))
+	ey := c[2].sub(c[0]).sub(ex.scale(i))
+	j := ey.length()
+	if j < epsilon {
+		return vector{}, vector{}, ErrUnreachable
+	}
+	ey = ey.scale(1 / j)
+	ez := ex.cross(ey)
+
+	x := (r[0]*r[0] - r[1]*r[1] + d*d) / (2 * d)
+	y := (r[0]*r[0]-r[2]*r[2]+i*i+j*j)/(2*j) - (i/j)*x
+	z2 := r[0]*r[0] - x*x - y*y
+	if z2 < -epsilon*r[0]*r[0] {
+		return vector{}, vector{}, ErrUnreachable
+	}
+	z := math.Sqrt(math.Max(z2, 0))
+
+	p := c[0].add(ex.scale(x)).add(ey.scale(y))
+	return p.sub(ez.scale(z)), p.add(ez.scale(z)), nil
+}
@@ -0,0 +1,81 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+// Hangprinter transforms Hangprinter kinematics. Motor positions are the line
+// lengths to the anchors A, B, C and D relative to their lengths at the origin.
+// Like in the firmware line D is driven by the fourth axis, so its coordinate is
+// ignored by Inverse and set to the position of line D by Forward.
+type Hangprinter struct {
+	// Anchors are the positions of the anchors A, B, C and D
+	Anchors [4][3]float64
+	// PrintRadius limits the reachable positions unless it is zero
+	PrintRadius float64
+	// origin are the line lengths at the origin
+	origin [4]float64
+}
+
+// NewHangprinter creates a transform from the given Hangprinter kinematics
+func NewHangprinter(hk *move.HangprinterKinematics) (*Hangprinter, error) {
+	h := &Hangprinter{PrintRadius: hk.PrintRadius}
+	for i, a := range [][]float64{hk.AnchorA, hk.AnchorB, hk.AnchorC} {
+		if len(a) < 3 {
+			return nil, errors.New("Hangprinter anchors require three coordinates")
+		}
+		copy(h.Anchors[i][:], a)
+	}
+	h.Anchors[3] = [3]float64{0, 0, hk.AnchorDz}
+	for i, a := range h.Anchors {
+		h.origin[i] = vector(a).length()
+	}
+	return h, nil
+}
+
+// Forward calculates the effector position from the line lengths.
+// The lines A, B and C define the position and line D selects the mirror image.
+func (h *Hangprinter) Forward(motors []float64) ([]float64, error) {
+	if len(motors) < 4 {
+		return nil, ErrTooFewAxes
+	}
+	var centers [3]vector
+	var lengths [3]float64
+	for i := range centers {
+		centers[i] = h.Anchors[i]
+		lengths[i] = motors[i] + h.origin[i]
+	}
+	p, q, err := trilaterate(centers, lengths)
+	if err != nil {
+		return nil, err
+	}
+	d := motors[3] + h.origin[3]
+	if math.Abs(q.sub(h.Anchors[3]).length()-d) < math.Abs(p.sub(h.Anchors[3]).length()-d) {
+		p = q
+	}
+	return passThrough([]float64{p[0], p[1], p[2], motors[3]}, motors, 4), nil
+}
+
+// Inverse calculates the line lengths of the given effector position
+func (h *Hangprinter) Inverse(coords []float64) ([]float64, error) {
+	if len(coords) < 3 {
+		return nil, ErrTooFewAxes
+	}
+	p := vector{coords[0], coords[1], coords[2]}
+	if h.PrintRadius > 0 && p[0]*p[0]+p[1]*p[1] > h.PrintRadius*h.PrintRadius*(1+epsilon) {
+		return nil, ErrUnreachable
+	}
+	if p[2] > h.Anchors[3][2] {
+		return nil, ErrUnreachable
+	}
+
+	motors := make([]float64, 4, len(coords)+1)
+	for i, a := range h.Anchors {
+		motors[i] = p.sub(a).length() - h.origin[i]
+	}
+	return passThrough(motors, coords, 4), nil
+}
@@ -0,0 +1,138 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+var (
+	// ErrUnreachable is returned if a position cannot be reached by the machine
+	ErrUnreachable = errors.New("Position is unreachable")
+	// ErrTooFewAxes is returned if fewer than the required number of values are passed
+	ErrTooFewAxes = errors.New("Too few axes")
+	// ErrUnsupported is returned by New if the kinematics cannot be transformed
+	ErrUnsupported = errors.New("Unsupported kinematics")
+)
+
+// Transform converts between motor positions and cartesian coordinates
+type Transform interface {
+	// Forward calculates the cartesian coordinates of the given motor positions
+	Forward(motors []float64) ([]float64, error)
+	// Inverse calculates the motor positions of the given cartesian coordinates
+	Inverse(coords []float64) ([]float64, error)
+}
+
+// New creates the transform for the given kinematics of the object model.
+// SCARA arms are not supported because their geometry is not part of the object
+// model, use NewScara instead. Rotary delta and five-bar SCARA kinematics are not
+// supported either.
+func New(k move.Kinematics) (Transform, error) {
+	switch v := k.Value.(type) {
+	case *move.CoreKinematics:
+		return NewCore(v)
+	case *move.DeltaKinematics:
+		if v.Name == move.RotaryDelta {
+			break
+		}
+		return NewDelta(v)
+	case *move.HangprinterKinematics:
+		return NewHangprinter(v)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupported, k.GetName())
+}
+
+// MoveError is returned by CheckMove if a part of a move is unreachable
+type MoveError struct {
+	// Position that cannot be reached
+	Position []float64
+	// Err is the reason
+	Err error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("Cannot reach %v: %s", e.Position, e.Err)
+}
+
+// Unwrap returns the reason of this error
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+// Reachable returns true if the given coordinates can be reached
+func Reachable(t Transform, coords []float64) bool {
+	_, err := t.Inverse(coords)
+	return err == nil
+}
+
+// CheckMove verifies that every point of the straight move between the given
+// coordinates can be reached. The line is sampled so that the distance between
+// two samples does not exceed resolution (in mm).
+func CheckMove(t Transform, from, to []float64, resolution float64) error {
+	if len(to) < len(from) {
+		return ErrTooFewAxes
+	}
+	var length float64
+	for i := range from {
+		length += (to[i] - from[i]) * (to[i] - from[i])
+	}
+	samples := 1
+	if resolution > 0 {
+		samples = int(math.Ceil(math.Sqrt(length) / resolution))
+		if samples < 1 {
+			samples = 1
+		}
+	}
+
+	pos := make([]float64, len(from))
+	for s := 0; s <= samples; s++ {
+		f := float64(s) / float64(samples)
+		for i := range pos {
+			pos[i] = from[i] + (to[i]-from[i])*f
+		}
+		if _, err := t.Inverse(pos); err != nil {
+			return &MoveError{Position: append([]float64(nil), pos...), Err: err}
+		}
+	}
+	return nil
+}
+
+// MotorPositions calculates the motor positions of the machine positions of the given axes
+func MotorPositions(t Transform, axes []move.Axis) ([]float64, error) {
+	coords := make([]float64, len(axes))
+	for i, a := range axes {
+		if a.MachinePosition == nil {
+			return nil, fmt.Errorf("Machine position of axis %s is unknown", a.Letter)
+		}
+		coords[i] = *a.MachinePosition
+	}
+	return t.Inverse(coords)
+}
+
+// MotorSteps calculates the motor positions of the machine positions of the given
+// axes in microsteps using their StepsPerMm
+func MotorSteps(t Transform, axes []move.Axis) ([]int64, error) {
+	motors, err := MotorPositions(t, axes)
+	if err != nil {
+		return nil, err
+	}
+	if len(motors) > len(axes) {
+		return nil, ErrTooFewAxes
+	}
+	steps := make([]int64, len(motors))
+	for i, m := range motors {
+		steps[i] = int64(math.Round(m * axes[i].StepsPerMm))
+	}
+	return steps, nil
+}
+
+// passThrough copies the values following the first n ones from src to dst
+func passThrough(dst, src []float64, n int) []float64 {
+	for i := n; i < len(src); i++ {
+		dst = append(dst, src[i])
+	}
+	return dst
+}
@@ -0,0 +1,122 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+// fromModel creates a transform from the JSON kinematics of an object model
+func fromModel(t *testing.T, data string) Transform {
+	t.Helper()
+	var k move.Kinematics
+	if err := json.Unmarshal([]byte(data), &k); err != nil {
+		t.Fatalf("Failed to parse kinematics: %v", err)
+	}
+	tr, err := New(k)
+	if err != nil {
+		t.Fatalf("Failed to create transform: %v", err)
+	}
+	return tr
+}
+
+func approxEqual(a, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRoundTrip(t *testing.T) {
+	coreXY := fromModel(t, `{"name":"coreXY"}`)
+	coreXZ := fromModel(t, `{"name":"coreXZ"}`)
+	markForged := fromModel(t, `{"name":"markForged","forwardMatrix":[[1,0,0],[0,1,0],[0,0,1]],"inverseMatrix":[[1,1,0],[0,1,0],[0,0,1]]}`)
+	delta := fromModel(t, `{"name":"delta","deltaRadius":105.6,"homedHeight":250,"printRadius":85,
+		"towers":[{"diagonal":215,"angleCorrection":0.3,"endstopAdjustment":-0.2},{"diagonal":215.2,"angleCorrection":-0.1},{"diagonal":214.9}],
+		"xTilt":0.001,"yTilt":-0.002}`)
+	hangprinter := fromModel(t, `{"name":"Hangprinter","anchorA":[0,-2000,-100],"anchorB":[2000,1000,-100],
+		"anchorC":[-2000,1000,-100],"anchorDz":3000,"printRadius":1500}`)
+	scara := NewScara(250, 200)
+	scara.Crosstalk = [3]float64{0.1, 0.01, -0.02}
+	scara.XOffset, scara.YOffset = -50, 100
+
+	tests := []struct {
+		name   string
+		tr     Transform
+		coords []float64
+	}{
+		{"CoreXY", coreXY, []float64{10, -20, 5}},
+		{"CoreXY with extruder", coreXY, []float64{150, 75.5, 0.2, 12.5}},
+		{"CoreXZ", coreXZ, []float64{-30, 40, 12}},
+		{"custom matrix", markForged, []float64{100, 50, 20}},
+		{"delta centre", delta, []float64{0, 0, 10}},
+		{"delta edge", delta, []float64{60, -55, 0.3}},
+		{"delta with extruder", delta, []float64{-40, 30, 100, 7}},
+		{"Hangprinter origin", hangprinter, []float64{0, 0, 0}},
+		{"Hangprinter", hangprinter, []float64{300, -450, 800}},
+		{"SCARA", scara, []float64{100, 150, 5}},
+		{"SCARA far", scara, []float64{300, 120, -2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			motors, err := tt.tr.Inverse(tt.coords)
+			if err != nil {
+				t.Fatalf("Inverse(%v) failed: %v", tt.coords, err)
+			}
+			coords, err := tt.tr.Forward(motors)
+			if err != nil {
+				t.Fatalf("Forward(%v) failed: %v", motors, err)
+			}
+			if len(coords) > len(tt.coords) {
+				// Hangprinter reports the position of line D as fourth coordinate
+				coords = coords[:len(tt.coords)]
+			}
+			if !approxEqual(coords, tt.coords, 1e-6) {
+				t.Errorf("Forward(Inverse(%v)) = %v", tt.coords, coords)
+			}
+		})
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	delta := fromModel(t, `{"name":"delta","deltaRadius":105.6,"homedHeight":250,"printRadius":85,
+		"towers":[{"diagonal":215},{"diagonal":215},{"diagonal":215}]}`)
+	hangprinter := fromModel(t, `{"name":"Hangprinter","anchorA":[0,-2000,-100],"anchorB":[2000,1000,-100],
+		"anchorC":[-2000,1000,-100],"anchorDz":3000,"printRadius":1500}`)
+
+	tests := []struct {
+		name   string
+		tr     Transform
+		coords []float64
+	}{
+		{"delta outside print radius", delta, []float64{90, 0, 10}},
+		{"delta above homed height", delta, []float64{0, 0, 260}},
+		{"Hangprinter above anchor D", hangprinter, []float64{0, 0, 3100}},
+		{"SCARA out of reach", NewScara(250, 200), []float64{500, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.tr.Inverse(tt.coords); !errors.Is(err, ErrUnreachable) {
+				t.Errorf("Inverse(%v) returned %v, expected ErrUnreachable", tt.coords, err)
+			}
+		})
+	}
+}
+
+func TestSingularMatrix(t *testing.T) {
+	ck := &move.CoreKinematics{InverseMatrix: [][]float64{{1, 1, 0}, {1, 1, 0}, {0, 0, 1}}}
+	ck.Name = move.CoreXY
+	ck.ForwardMatrix = move.DefaultForwardMatrix()
+	if _, err := NewCore(ck); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("NewCore returned %v, expected ErrSingularMatrix", err)
+	}
+}
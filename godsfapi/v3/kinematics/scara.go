@@ -0,0 +1,91 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package kinematics
+
+import "math"
+
+const (
+	// DefaultScaraThetaMin is the default minimum angle of the proximal arm
+	DefaultScaraThetaMin = -90.0
+	// DefaultScaraThetaMax is the default maximum angle of the proximal arm
+	DefaultScaraThetaMax = 90.0
+	// DefaultScaraPsiMin is the default minimum angle of the distal arm
+	DefaultScaraPsiMin = -135.0
+	// DefaultScaraPsiMax is the default maximum angle of the distal arm
+	DefaultScaraPsiMax = 135.0
+)
+
+// Scara transforms SCARA kinematics. Motor positions are the angle of the
+// proximal arm and the angle of the distal arm relative to it (in degrees)
+// followed by the Z position. The geometry is configured by M669 but it is not
+// reported by the object model.
+type Scara struct {
+	// ProximalArmLength in mm
+	ProximalArmLength float64
+	// DistalArmLength in mm
+	DistalArmLength float64
+	// ThetaLimits are the minimum and maximum angles of the proximal arm
+	ThetaLimits [2]float64
+	// PsiLimits are the minimum and maximum angles of the distal arm
+	PsiLimits [2]float64
+	// Crosstalk is the movement of the distal arm per degree of the proximal arm,
+	// followed by the Z movement per degree of the proximal and distal arms
+	Crosstalk [3]float64
+	// XOffset is the X coordinate of the bed origin relative to the proximal joint
+	XOffset float64
+	// YOffset is the Y coordinate of the bed origin relative to the proximal joint
+	YOffset float64
+}
+
+// NewScara creates a SCARA transform with default angle limits
+func NewScara(proximalArmLength, distalArmLength float64) *Scara {
+	return &Scara{
+		ProximalArmLength: proximalArmLength,
+		DistalArmLength:   distalArmLength,
+		ThetaLimits:       [2]float64{DefaultScaraThetaMin, DefaultScaraThetaMax},
+		PsiLimits:         [2]float64{DefaultScaraPsiMin, DefaultScaraPsiMax},
+	}
+}
+
+// Forward calculates the position of the distal arm end from the arm angles
+func (s *Scara) Forward(motors []float64) ([]float64, error) {
+	if len(motors) < 3 {
+		return nil, ErrTooFewAxes
+	}
+	theta := motors[0]
+	psi := motors[1] + theta*s.Crosstalk[0]
+	t, tp := theta*math.Pi/180, (theta+psi)*math.Pi/180
+
+	x := math.Cos(t)*s.ProximalArmLength + math.Cos(tp)*s.DistalArmLength - s.XOffset
+	y := math.Sin(t)*s.ProximalArmLength + math.Sin(tp)*s.DistalArmLength - s.YOffset
+	z := motors[2] + theta*s.Crosstalk[1] + psi*s.Crosstalk[2]
+	return passThrough([]float64{x, y, z}, motors, 3), nil
+}
+
+// Inverse calculates the arm angles of the given position. If both arm
+// configurations are within the limits, the one with a positive distal angle
+// is returned.
+func (s *Scara) Inverse(coords []float64) ([]float64, error) {
+	if len(coords) < 3 {
+		return nil, ErrTooFewAxes
+	}
+	x, y := coords[0]+s.XOffset, coords[1]+s.YOffset
+	l1, l2 := s.ProximalArmLength, s.DistalArmLength
+	cosPsi := (x*x + y*y - l1*l1 - l2*l2) / (2 * l1 * l2)
+	if math.IsNaN(cosPsi) || math.Abs(cosPsi) > 1+epsilon {
+		return nil, ErrUnreachable
+	}
+	cosPsi = math.Max(-1, math.Min(1, cosPsi))
+
+	for _, sign := range []float64{1, -1} {
+		sinPsi := sign * math.Sqrt(1-cosPsi*cosPsi)
+		k1, k2 := l1+l2*cosPsi, l2*sinPsi
+		theta := math.Atan2(k1*y-k2*x, k1*x+k2*y) * 180 / math.Pi
+		psi := math.Atan2(sinPsi, cosPsi) * 180 / math.Pi
+		if theta < s.ThetaLimits[0] || theta > s.ThetaLimits[1] || psi < s.PsiLimits[0] || psi > s.PsiLimits[1] {
+			continue
+		}
+		z := coords[2] - theta*s.Crosstalk[1] - psi*s.Crosstalk[2]
+		return passThrough([]float64{theta, psi - theta*s.Crosstalk[0], z}, coords, 3), nil
+	}
+	return nil, ErrUnreachable
+}
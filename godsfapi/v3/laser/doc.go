@@ -0,0 +1,13 @@
+/*
+Package laser turns bitmaps into raster engraving G-code for laser mode.
+
+A Raster converts an image to laser power using greyscale mapping, dithering
+or a threshold and scans it line by line, optionally in both directions and
+with overscan so that the laser only fires at constant speed. Power is emitted
+through the S parameter of G1 moves and is specified from 0 to 1 like
+CurrentMove.LaserPwm. The G-code is generated one scan line at a time so that
+it can be streamed through a command connection or written to a file in the
+G-code directory. Check verifies the machine mode and the axis limits first.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package laser
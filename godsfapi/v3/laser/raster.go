@@ -0,0 +1,306 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package laser
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+)
+
+// Default values for Options
+const (
+	DefaultResolution  = 0.1
+	DefaultSpeed       = 50.0
+	DefaultTravelSpeed = 100.0
+	DefaultPwmScale    = 255.0
+	DefaultThreshold   = 0.5
+)
+
+var (
+	// ErrNotLaser is returned if the machine is not in laser mode
+	ErrNotLaser = errors.New("Machine is not in laser mode")
+	// ErrOutOfBounds is returned if the engraving exceeds the axis limits
+	ErrOutOfBounds = errors.New("Engraving exceeds the axis limits")
+	// ErrInvalidOptions is returned if the options cannot be used
+	ErrInvalidOptions = errors.New("Invalid raster options")
+)
+
+// Mode is how pixels are converted to laser power
+type Mode int
+
+const (
+	// Greyscale maps the darkness of every pixel linearly to MinPower..MaxPower
+	Greyscale Mode = iota
+	// Dither fires MaxPower or nothing and distributes the error (Floyd-Steinberg)
+	Dither
+	// Threshold fires MaxPower for pixels that are darker than Threshold
+	Threshold
+)
+
+// Options control the generated G-code
+type Options struct {
+	// X is the left edge of the engraving (in mm)
+	X float64
+	// Y is the bottom edge of the engraving (in mm)
+	Y float64
+	// Resolution is the size of a pixel and the distance of scan lines (in mm)
+	Resolution float64
+	// Mode to convert pixels to laser power
+	Mode Mode
+	// Threshold is the darkness from 0 to 1 at which Threshold mode fires
+	Threshold float64
+	// Invert engraves light instead of dark pixels
+	Invert bool
+	// MinPower is the power of the lightest pixel that is engraved in Greyscale mode (0..1)
+	MinPower float64
+	// MaxPower is the power of black pixels (0..1)
+	MaxPower float64
+	// PwmScale is the S value of full power as configured by M452 R
+	PwmScale float64
+	// Speed of engraving moves (in mm/s)
+	Speed float64
+	// TravelSpeed of moves between scan lines (in mm/s)
+	TravelSpeed float64
+	// Overscan is the distance to accelerate before and decelerate after every line (in mm)
+	Overscan float64
+	// Bidirectional scans every other line from right to left
+	Bidirectional bool
+}
+
+// DefaultOptions returns the default options
+func DefaultOptions() Options {
+	return Options{
+		Resolution:    DefaultResolution,
+		Threshold:     DefaultThreshold,
+		MaxPower:      1,
+		PwmScale:      DefaultPwmScale,
+		Speed:         DefaultSpeed,
+		TravelSpeed:   DefaultTravelSpeed,
+		Bidirectional: true,
+	}
+}
+
+// Raster generates engraving G-code for an image
+type Raster struct {
+	opts   Options
+	img    image.Image
+	width  int
+	height int
+
+	// row is the next image row to scan, counting from the bottom
+	row      int
+	lines    int
+	started  bool
+	finished bool
+	// errors holds the dithering errors for the next row
+	errors []float64
+}
+
+// NewRaster creates a Raster for the given image
+func NewRaster(img image.Image, opts Options) (*Raster, error) {
+	b := img.Bounds()
+	switch {
+	case b.Empty():
+		return nil, fmt.Errorf("%w: image is empty", ErrInvalidOptions)
+	case opts.Resolution <= 0, opts.Speed <= 0, opts.TravelSpeed <= 0, opts.PwmScale <= 0, opts.Overscan < 0:
+		return nil, fmt.Errorf("%w: resolution, speeds and PWM scale must be positive", ErrInvalidOptions)
+	case opts.MinPower < 0, opts.MaxPower > 1, opts.MinPower > opts.MaxPower:
+		return nil, fmt.Errorf("%w: power must be within 0..1", ErrInvalidOptions)
+	}
+	return &Raster{opts: opts, img: img, width: b.Dx(), height: b.Dy(), errors: make([]float64, b.Dx()+2)}, nil
+}
+
+// Extent returns the area covered by the laser head including the overscan (in mm)
+func (r *Raster) Extent() (minX, maxX, minY, maxY float64) {
+	o := &r.opts
+	return o.X - o.Overscan, o.X + float64(r.width)*o.Resolution + o.Overscan,
+		o.Y, o.Y + float64(r.height)*o.Resolution
+}
+
+// Check returns an error if the machine is not in laser mode or the engraving
+// exceeds the limits of the X or Y axis
+func (r *Raster) Check(m *machine.MachineModel) error {
+	if m.State.MachineMode != state.Laser {
+		return ErrNotLaser
+	}
+	minX, maxX, minY, maxY := r.Extent()
+	for _, limit := range []struct {
+		letter   string
+		min, max float64
+	}{{"X", minX, maxX}, {"Y", minY, maxY}} {
+		found := false
+		for _, a := range m.Move.Axes {
+			if a.Letter != limit.letter {
+				continue
+			}
+			found = true
+			if limit.min < a.Min || limit.max > a.Max {
+				return fmt.Errorf("%w: %s %.3f..%.3f is outside %.3f..%.3f", ErrOutOfBounds, a.Letter, limit.min, limit.max, a.Min, a.Max)
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: axis %s does not exist", ErrOutOfBounds, limit.letter)
+		}
+	}
+	return nil
+}
+
+// darkness returns how much a pixel is to be engraved from 0 to 1.
+// Transparent pixels are never engraved.
+func (r *Raster) darkness(c color.Color) float64 {
+	g := color.Gray16Model.Convert(c).(color.Gray16)
+	_, _, _, a := c.RGBA()
+	if a == 0 {
+		return 0
+	}
+	// Blend with a white background
+	l := math.Max(0, math.Min(1, (float64(g.Y)+float64(0xffff-a))/0xffff))
+	if r.opts.Invert {
+		return l
+	}
+	return 1 - l
+}
+
+// powers returns the S values of the given image row
+func (r *Raster) powers(y int) []float64 {
+	o := &r.opts
+	b := r.img.Bounds()
+	result := make([]float64, r.width)
+	next := make([]float64, r.width+2)
+	for x := 0; x < r.width; x++ {
+		d := r.darkness(r.img.At(b.Min.X+x, y))
+		var p float64
+		switch o.Mode {
+		case Greyscale:
+			if d > 0 {
+				p = o.MinPower + d*(o.MaxPower-o.MinPower)
+			}
+		case Dither:
+			d += r.errors[x+1]
+			out := 0.0
+			if d >= 0.5 {
+				out, p = 1, o.MaxPower
+			}
+			e := d - out
+			r.errors[x+2] += e * 7 / 16
+			next[x] += e * 3 / 16
+			next[x+1] += e * 5 / 16
+			next[x+2] += e / 16
+		case Threshold:
+			if d > 0 && d >= o.Threshold {
+				p = o.MaxPower
+			}
+		}
+		result[x] = math.Round(p*o.PwmScale*1000) / 1000
+	}
+	r.errors = next
+	return result
+}
+
+// Next returns the G-code of the next scan line. Lines without anything to
+// engrave are skipped. io.EOF is returned after the last line.
+func (r *Raster) Next() ([]string, error) {
+	o := &r.opts
+	if !r.started {
+		r.started = true
+		minX, maxX, minY, maxY := r.Extent()
+		return []string{
+			fmt.Sprintf("; Laser raster %dx%d px at %.3f mm/px, X%.3f..%.3f Y%.3f..%.3f", r.width, r.height, o.Resolution, minX, maxX, minY, maxY),
+			"G21",
+			"G90",
+		}, nil
+	}
+
+	for r.row < r.height {
+		// Rows are scanned from the bottom of the image upwards
+		imgRow := r.img.Bounds().Max.Y - 1 - r.row
+		y := o.Y + (float64(r.row)+0.5)*o.Resolution
+		r.row++
+		powers := r.powers(imgRow)
+		if lines := r.scanLine(powers, y); lines != nil {
+			return lines, nil
+		}
+	}
+
+	if !r.finished {
+		r.finished = true
+		return []string{"G1 S0", "M400"}, nil
+	}
+	return nil, io.EOF
+}
+
+// scanLine returns the moves engraving a single line or nil if it is blank
+func (r *Raster) scanLine(powers []float64, y float64) []string {
+	first, last := -1, -1
+	for i, p := range powers {
+		if p > 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+
+	o := &r.opts
+	edge := func(i int) float64 { return o.X + float64(i)*o.Resolution }
+	reverse := o.Bidirectional && r.lines%2 == 1
+	r.lines++
+
+	var lines []string
+	start, end, dir := edge(first), edge(last+1), 1.0
+	if reverse {
+		start, end, dir = end, start, -1
+	}
+	lines = append(lines,
+		fmt.Sprintf("G0 X%.3f Y%.3f F%g", start-dir*o.Overscan, y, o.TravelSpeed*60),
+		fmt.Sprintf("G1 X%.3f S0 F%g", start, o.Speed*60))
+
+	// Merge pixels of the same power into single moves
+	emit := func(to int, p float64) {
+		lines = append(lines, fmt.Sprintf("G1 X%.3f S%g", edge(to), p))
+	}
+	if !reverse {
+		for i := first; i <= last; i++ {
+			if i == last || powers[i+1] != powers[i] {
+				emit(i+1, powers[i])
+			}
+		}
+	} else {
+		for i := last; i >= first; i-- {
+			if i == first || powers[i-1] != powers[i] {
+				emit(i, powers[i])
+			}
+		}
+	}
+	lines = append(lines, fmt.Sprintf("G1 X%.3f S0", end+dir*o.Overscan))
+	return lines
+}
+
+// WriteTo writes the complete G-code
+func (r *Raster) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for {
+		lines, err := r.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		c, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+		n += int64(c)
+		if err != nil {
+			return n, err
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package laser
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/directories"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// PathResolver converts firmware paths into real paths, e.g. a connection.CommandConnection
+type PathResolver interface {
+	ResolvePath(path string) (string, error)
+}
+
+// Send performs the G-code scan line by scan line and stops at the first error
+func (r *Raster) Send(p CodePerformer, channel types.CodeChannel) error {
+	for {
+		lines, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reply, err := p.PerformSimpleCode(strings.Join(lines, "\n"), channel)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(reply, "Error") {
+			return fmt.Errorf("Engraving failed: %s", strings.TrimSpace(reply))
+		}
+	}
+}
+
+// WriteFile writes the G-code to a file with the given name in the G-code directory
+// of the machine and returns the firmware path of the file
+func (r *Raster) WriteFile(res PathResolver, m *machine.MachineModel, name string) (string, error) {
+	dir := m.Directories.GCodes
+	if dir == "" {
+		dir = directories.DefaultGCodesPath
+	}
+	file := path.Join(dir, name)
+	local, err := res.ResolvePath(file)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Create(local)
+	if err != nil {
+		return "", err
+	}
+	if _, err = r.WriteTo(f); err != nil {
+		f.Close()
+		return "", err
+	}
+	return file, f.Close()
+}
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package boards
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Board holds information about the electronics used
 type Board struct {
 	// BootloaderFileName is filename of firmware binary
@@ -41,6 +43,20 @@ type Board struct {
 	V12 *MinMaxCurrent `json:"v12"`
 	// VIn represents input voltage details of the main board in V or nil if unknown
 	VIn *MinMaxCurrent `json:"vIn"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (b *Board) UnmarshalJSON(data []byte) error {
+	type board Board
+	return extra.Unmarshal(data, (*board)(b), &b.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (b Board) MarshalJSON() ([]byte, error) {
+	type board Board
+	return extra.Marshal(board(b), b.Extra)
 }
 
 // MinMaxCurrent represents a data structure to hold current, min and max values
@@ -51,6 +67,20 @@ type MinMaxCurrent struct {
 	Min float64 `json:"min"`
 	// Maximum value encountered
 	Max float64 `json:"max"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mmc *MinMaxCurrent) UnmarshalJSON(data []byte) error {
+	type minMaxCurrent MinMaxCurrent
+	return extra.Unmarshal(data, (*minMaxCurrent)(mmc), &mmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mmc MinMaxCurrent) MarshalJSON() ([]byte, error) {
+	type minMaxCurrent MinMaxCurrent
+	return extra.Marshal(minMaxCurrent(mmc), mmc.Extra)
 }
 
 // BoardState is a representation of possible expansion board states
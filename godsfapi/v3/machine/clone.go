@@ -0,0 +1,78 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package machine
+
+import "reflect"
+
+// Clone returns a deep copy of this instance that shares no data with it
+func (mm *MachineModel) Clone() *MachineModel {
+	if mm == nil {
+		return nil
+	}
+	return DeepCopy(mm).(*MachineModel)
+}
+
+// DeepCopy returns a deep copy of v which may be any object model type or a pointer
+// to one. Pointers, lists, maps and interfaces like Kinematics.Value are copied
+// recursively while unexported struct fields are copied as they are.
+func DeepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	src := reflect.ValueOf(v)
+	dst := reflect.New(src.Type()).Elem()
+	deepCopy(dst, src)
+	return dst.Interface()
+}
+
+func deepCopy(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		deepCopy(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		v := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(v, src.Elem())
+		dst.Set(v)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			v := reflect.New(iter.Value().Type()).Elem()
+			deepCopy(v, iter.Value())
+			m.SetMapIndex(iter.Key(), v)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				deepCopy(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package directories
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Default values
 const (
 	DefaultFilamentsPath = "0:/filaments"
@@ -31,6 +33,20 @@ type Directories struct {
 	System string `json:"system"`
 	// Web is the path to the web directory
 	Web string `json:"web"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (d *Directories) UnmarshalJSON(data []byte) error {
+	type directories Directories
+	return extra.Unmarshal(data, (*directories)(d), &d.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (d Directories) MarshalJSON() ([]byte, error) {
+	type directories Directories
+	return extra.Marshal(directories(d), d.Extra)
 }
 
 // NewDirectories returns an instance with all paths set to their defaults
@@ -0,0 +1,10 @@
+/*
+Package extra retains JSON members that are not known to the object model types.
+
+Every object model type keeps unknown members in a Fields map and writes them
+back when it is marshalled, so reading, modifying and writing model data does not
+lose anything newer DuetControlServer versions report. Patches are merged into
+retained members using the same semantics as for known fields.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package extra
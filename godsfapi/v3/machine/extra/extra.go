@@ -0,0 +1,301 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package extra
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Fields holds JSON members that are unknown to a type
+type Fields map[string]json.RawMessage
+
+// knownCache maps struct types to the lower-case names of their JSON members
+var knownCache sync.Map
+
+// Unmarshal decodes data into v and merges all members that are unknown to v
+// into fields. v must be a pointer to a struct without an UnmarshalJSON method,
+// usually a conversion of the type that calls Unmarshal. Embedded types may have
+// their own UnmarshalJSON method.
+func Unmarshal(data []byte, v interface{}, fields *Fields) error {
+	// Like encoding/json unknown members are still collected if a known field
+	// has an unexpected type and the first such error is returned at the end
+	var typeErr error
+	if rv := reflect.ValueOf(v).Elem(); promotesCodec(rv.Type()) {
+		typeErr = unmarshalMembers(data, rv)
+	} else {
+		typeErr = json.Unmarshal(data, v)
+	}
+	if _, ok := typeErr.(*json.UnmarshalTypeError); typeErr != nil && !ok {
+		return typeErr
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return typeErr
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	known := knownMembers(reflect.TypeOf(v).Elem())
+	for k, raw := range members {
+		if known[strings.ToLower(k)] {
+			continue
+		}
+		if *fields == nil {
+			*fields = make(Fields)
+		}
+		merged, err := Merge((*fields)[k], raw)
+		if err != nil {
+			return err
+		}
+		(*fields)[k] = merged
+	}
+	return typeErr
+}
+
+// Marshal encodes v and appends the given fields ordered by their names
+func Marshal(v interface{}, fields Fields) ([]byte, error) {
+	var b []byte
+	var err error
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Struct && promotesCodec(rv.Type()) {
+		b, err = marshalMembers(rv)
+	} else {
+		b, err = json.Marshal(v)
+	}
+	if err != nil || len(fields) == 0 || len(b) < 2 || b[len(b)-1] != '}' {
+		return b, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	needComma := len(b) > 2
+	for _, k := range keys {
+		if needComma {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		if len(fields[k]) == 0 {
+			buf.WriteString("null")
+		} else {
+			buf.Write(fields[k])
+		}
+		needComma = true
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Merge applies patch to the JSON value old. Objects are merged member by member,
+// lists item by item with the length of the patch and other values are replaced.
+func Merge(old, patch json.RawMessage) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(patch)
+	if len(old) == 0 || len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return append(json.RawMessage(nil), patch...), nil
+	}
+
+	o, err := decode(old)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeValues(o, p))
+}
+
+// decode decodes a JSON value preserving the exact representation of numbers
+func decode(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	err := d.Decode(&v)
+	return v, err
+}
+
+func mergeValues(old, patch interface{}) interface{} {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		o, ok := old.(map[string]interface{})
+		if !ok {
+			return p
+		}
+		for k, v := range p {
+			o[k] = mergeValues(o[k], v)
+		}
+		return o
+	case []interface{}:
+		o, ok := old.([]interface{})
+		if !ok {
+			return p
+		}
+		for i := range p {
+			if i < len(o) {
+				p[i] = mergeValues(o[i], p[i])
+			}
+		}
+		return p
+	default:
+		return patch
+	}
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// promotesCodec returns true if the given struct type gets JSON methods from an
+// embedded type. encoding/json would call these instead of handling all fields.
+func promotesCodec(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PtrTo(t)
+	return pt.Implements(unmarshalerType) || pt.Implements(marshalerType)
+}
+
+// member is a JSON member of a struct value
+type member struct {
+	name  string
+	value reflect.Value
+}
+
+// structMembers returns the JSON members of a struct value including the ones
+// of embedded structs in the order encoding/json uses
+func structMembers(v reflect.Value, result []member) []member {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			result = structMembers(v.Field(i), result)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		result = append(result, member{name: name, value: v.Field(i)})
+	}
+	return result
+}
+
+// unmarshalMembers decodes a JSON object member by member into the fields of v
+func unmarshalMembers(data []byte, v reflect.Value) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	members := structMembers(v, nil)
+	var typeErr error
+	for k, r := range raw {
+		var target *member
+		for i := range members {
+			if members[i].name == k {
+				target = &members[i]
+				break
+			}
+			if target == nil && strings.EqualFold(members[i].name, k) {
+				target = &members[i]
+			}
+		}
+		if target == nil {
+			continue
+		}
+		err := json.Unmarshal(r, target.value.Addr().Interface())
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			if typeErr == nil {
+				typeErr = err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	return typeErr
+}
+
+// marshalMembers encodes the fields of v member by member as JSON object
+func marshalMembers(v reflect.Value) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range structMembers(v, nil) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(m.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value.Interface())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// knownMembers returns the lower-case names of all JSON members of the given struct type
+func knownMembers(t reflect.Type) map[string]bool {
+	if known, ok := knownCache.Load(t); ok {
+		return known.(map[string]bool)
+	}
+	known := make(map[string]bool)
+	collectMembers(t, known)
+	knownCache.Store(t, known)
+	return known
+}
+
+func collectMembers(t reflect.Type, known map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectMembers(ft, known)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+}
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package fans
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Fan represents information about an attached fan
 type Fan struct {
 	// ActualValue is the current speed on a scale betweem 0 to 1 or -1 if unknown
@@ -22,6 +24,20 @@ type Fan struct {
 	Rpm int64 `json:"rpm"`
 	// Thermostatic control parameters
 	Thermostatic Thermostatic `json:"thermostatic"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (f *Fan) UnmarshalJSON(data []byte) error {
+	type fan Fan
+	return extra.Unmarshal(data, (*fan)(f), &f.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (f Fan) MarshalJSON() ([]byte, error) {
+	type fan Fan
+	return extra.Marshal(fan(f), f.Extra)
 }
 
 // Thermostatic parameters of a fan
@@ -34,4 +50,18 @@ type Thermostatic struct {
 	// LowTemperature is the lower temperature range required to turn
 	// on the fan (in degC)
 	LowTemperature *float64 `json:"lowTemperature"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (t *Thermostatic) UnmarshalJSON(data []byte) error {
+	type thermostatic Thermostatic
+	return extra.Unmarshal(data, (*thermostatic)(t), &t.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (t Thermostatic) MarshalJSON() ([]byte, error) {
+	type thermostatic Thermostatic
+	return extra.Marshal(thermostatic(t), t.Extra)
 }
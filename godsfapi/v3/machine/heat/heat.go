@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package heat
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 const (
 	// AbsoluteZero temperature in degC
 	AbsoluteZero = -273.15
@@ -44,6 +46,20 @@ type Heat struct {
 	ColdRetractTemperature float64 `json:"coldRetractTemperature"`
 	// Heaters is a list of configured heaters
 	Heaters []Heater `json:"heaters"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (h *Heat) UnmarshalJSON(data []byte) error {
+	type heat Heat
+	return extra.Unmarshal(data, (*heat)(h), &h.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (h Heat) MarshalJSON() ([]byte, error) {
+	type heat Heat
+	return extra.Marshal(heat(h), h.Extra)
 }
 
 // Default values for Heater
@@ -74,6 +90,20 @@ type Heater struct {
 	Standby float64 `json:"standby"`
 	// State of the heater
 	State *HeaterState `json:"state"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (h *Heater) UnmarshalJSON(data []byte) error {
+	type heater Heater
+	return extra.Unmarshal(data, (*heater)(h), &h.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (h Heater) MarshalJSON() ([]byte, error) {
+	type heater Heater
+	return extra.Marshal(heater(h), h.Extra)
 }
 
 // Default values for HeaterModel
@@ -106,6 +136,20 @@ type HeaterModel struct {
 	TimeConstant float64 `json:"timeConstant"`
 	// TimeConstantFanOn value with fan on
 	TimeConstantFanOn float64 `json:"timeConstantFanOn"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (hm *HeaterModel) UnmarshalJSON(data []byte) error {
+	type heaterModel HeaterModel
+	return extra.Unmarshal(data, (*heaterModel)(hm), &hm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (hm HeaterModel) MarshalJSON() ([]byte, error) {
+	type heaterModel HeaterModel
+	return extra.Marshal(heaterModel(hm), hm.Extra)
 }
 
 // HeaterModelPID holds details about the PID model of a heater
@@ -120,6 +164,20 @@ type HeaterModelPID struct {
 	D float64 `json:"d"`
 	// Used indicates usage of PID control (instead of bang-bang)
 	Used bool `json:"used"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (hmp *HeaterModelPID) UnmarshalJSON(data []byte) error {
+	type heaterModelPID HeaterModelPID
+	return extra.Unmarshal(data, (*heaterModelPID)(hmp), &hmp.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (hmp HeaterModelPID) MarshalJSON() ([]byte, error) {
+	type heaterModelPID HeaterModelPID
+	return extra.Marshal(heaterModelPID(hmp), hmp.Extra)
 }
 
 // HeaterMonitorAction is the action to take when a heater monitor is triggered
@@ -158,4 +216,18 @@ type HeaterMonitor struct {
 	Condition HeaterMonitorCondition `json:"condition"`
 	// Limit threshold for this heater monitor
 	Limit *float64 `json:"limit"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (hm *HeaterMonitor) UnmarshalJSON(data []byte) error {
+	type heaterMonitor HeaterMonitor
+	return extra.Unmarshal(data, (*heaterMonitor)(hm), &hm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (hm HeaterMonitor) MarshalJSON() ([]byte, error) {
+	type heaterMonitor HeaterMonitor
+	return extra.Marshal(heaterMonitor(hm), hm.Extra)
 }
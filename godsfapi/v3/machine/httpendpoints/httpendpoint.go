@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package httpendpoints
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 const (
 	// RepRapFirmwareNamespace is the namespace used for rr_ requests
 	RepRapFirmwareNamespace = "rr_"
@@ -21,6 +23,20 @@ type HttpEndpoint struct {
 	IsUploadRequest bool `json:"isUploadRequest"`
 	// UnixSocket is the path to the corresponding UNIX socket
 	UnixSocket string `json:"unixSocket"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (he *HttpEndpoint) UnmarshalJSON(data []byte) error {
+	type httpEndpoint HttpEndpoint
+	return extra.Unmarshal(data, (*httpEndpoint)(he), &he.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (he HttpEndpoint) MarshalJSON() ([]byte, error) {
+	type httpEndpoint HttpEndpoint
+	return extra.Marshal(httpEndpoint(he), he.Extra)
 }
 
 // HttpEndpointType represents supported HTTP request types
@@ -1,7 +1,10 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package inputs
 
-import "github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+import (
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
 
 // Compatibility level for emulation
 type Compatibility string
@@ -78,6 +81,20 @@ type InputChannel struct {
 	LineNumber int64 `json:"lineNumber"`
 	// Volumetric represents usage of volumetric extrusion
 	Volumetric bool `json:"volumetric"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (ic *InputChannel) UnmarshalJSON(data []byte) error {
+	type inputChannel InputChannel
+	return extra.Unmarshal(data, (*inputChannel)(ic), &ic.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (ic InputChannel) MarshalJSON() ([]byte, error) {
+	type inputChannel InputChannel
+	return extra.Marshal(inputChannel(ic), ic.Extra)
 }
 
 // NewInputChannel returns an InputChannel with default values set
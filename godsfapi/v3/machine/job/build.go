@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package job
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // BuildObject holds information about a detected build object
 type BuildObject struct {
 	// Cancelled indicates if this build object is cancelled
@@ -11,6 +13,20 @@ type BuildObject struct {
 	X []*float64 `json:"x"`
 	// Y coordinates of the build object (in mm or nil if not found)
 	Y []*float64 `json:"y"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (bo *BuildObject) UnmarshalJSON(data []byte) error {
+	type buildObject BuildObject
+	return extra.Unmarshal(data, (*buildObject)(bo), &bo.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (bo BuildObject) MarshalJSON() ([]byte, error) {
+	type buildObject BuildObject
+	return extra.Marshal(buildObject(bo), bo.Extra)
 }
 
 // Build holds information about the current build
@@ -24,4 +40,18 @@ type Build struct {
 	M486Numbers bool `json:"m486Numbers"`
 	// Objects is a list of detected objects
 	Objects []BuildObject `json:"objects"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (b *Build) UnmarshalJSON(data []byte) error {
+	type build Build
+	return extra.Unmarshal(data, (*build)(b), &b.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (b Build) MarshalJSON() ([]byte, error) {
+	type build Build
+	return extra.Marshal(build(b), b.Extra)
 }
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package job
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Job holds information about the current file job (if any)
 type Job struct {
 	// Build holds information about the current build or nil if not available
@@ -12,8 +14,8 @@ type Job struct {
 	// FilePosition is the current position in the file being processed in bytes
 	FilePosition *uint64 `json:"filePosition"`
 	// FirstLayerDuration is the duration of the first layer in s or nil if not available
-	// Deprecated: No longer used, will always be nil
-	FirstLayerDuration *int64 `json:"-"`
+	// Deprecated: No longer reported by current firmware versions
+	FirstLayerDuration *int64 `json:"firstLayerDuration"`
 	// LastDuration is the total duration of the last job in s or nil if not available
 	LastDuration *int64 `json:"lastDuration"`
 	// LastFileName is the name of the last processed file
@@ -36,6 +38,20 @@ type Job struct {
 	TimesLeft TimesLeft `json:"timesLeft"`
 	// WarmUpDuration is the time needed to heat up the heaters in s or nil if unknown
 	WarmUpDuration *int64 `json:"warmUpDuration"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (j *Job) UnmarshalJSON(data []byte) error {
+	type job Job
+	return extra.Unmarshal(data, (*job)(j), &j.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (j Job) MarshalJSON() ([]byte, error) {
+	type job Job
+	return extra.Marshal(job(j), j.Extra)
 }
 
 // Layer holds information about a layer from a file being printed
@@ -52,6 +68,20 @@ type Layer struct {
 	Height float64 `json:"height"`
 	// Temparatures are the last heater temparatures (in degC or nil if unknown)
 	Temperatures []*float64 `json:"temperatures"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (l *Layer) UnmarshalJSON(data []byte) error {
+	type layer Layer
+	return extra.Unmarshal(data, (*layer)(l), &l.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (l Layer) MarshalJSON() ([]byte, error) {
+	type layer Layer
+	return extra.Marshal(layer(l), l.Extra)
 }
 
 // TimesLeft holds information about estimated remaining times
@@ -61,8 +91,22 @@ type TimesLeft struct {
 	// Filament consumption based estimation in s (nil if unknown)
 	Filament *int64 `json:"filament"`
 	// Layer progress based estimation in s (nil if unknown)
-	// Deprecated: No longer used, will always return nil
-	Layer *int64 `json:"-"`
+	// Deprecated: No longer reported by current firmware versions
+	Layer *int64 `json:"layer"`
 	// Slicer is time left base on slicer reports (see M73, in s or nil)
 	Slicer *int64 `json:"slicer"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (tl *TimesLeft) UnmarshalJSON(data []byte) error {
+	type timesLeft TimesLeft
+	return extra.Unmarshal(data, (*timesLeft)(tl), &tl.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (tl TimesLeft) MarshalJSON() ([]byte, error) {
+	type timesLeft TimesLeft
+	return extra.Marshal(timesLeft(tl), tl.Extra)
 }
@@ -3,6 +3,8 @@ package job
 
 import (
 	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 )
 
 // Thumbnail holds image parsed out of GCode files
@@ -14,6 +16,20 @@ type Thumbnail struct {
 	Height int64 `json:"height"`
 	// Width of thumbail
 	Width int64 `json:"width"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (t *Thumbnail) UnmarshalJSON(data []byte) error {
+	type thumbnail Thumbnail
+	return extra.Unmarshal(data, (*thumbnail)(t), &t.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (t Thumbnail) MarshalJSON() ([]byte, error) {
+	type thumbnail Thumbnail
+	return extra.Marshal(thumbnail(t), t.Extra)
 }
 
 // ParsedFileInfo holds information about a parsed G-code file
@@ -42,4 +58,18 @@ type ParsedFileInfo struct {
 	Size uint64 `json:"size"`
 	// Thumbnails is a collection of thumbnails parsed from GCode
 	Thumbnails []Thumbnail `json:"thumbnails"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pfi *ParsedFileInfo) UnmarshalJSON(data []byte) error {
+	type parsedFileInfo ParsedFileInfo
+	return extra.Unmarshal(data, (*parsedFileInfo)(pfi), &pfi.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pfi ParsedFileInfo) MarshalJSON() ([]byte, error) {
+	type parsedFileInfo ParsedFileInfo
+	return extra.Marshal(parsedFileInfo(pfi), pfi.Extra)
 }
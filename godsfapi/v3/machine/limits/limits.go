@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package limits
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Limits configured for the machine
 type Limits struct {
 	// Axes is the maximum number of axes or nil if unknown
@@ -53,4 +55,18 @@ type Limits struct {
 	ZProbeProgramBytes *int64 `json:"zProbeProgramBytes"`
 	// ZProbes is the maximum number of Z-probes or nil if unknown
 	ZProbes *int64 `json:"zProbes"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (l *Limits) UnmarshalJSON(data []byte) error {
+	type limits Limits
+	return extra.Unmarshal(data, (*limits)(l), &l.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (l Limits) MarshalJSON() ([]byte, error) {
+	type limits Limits
+	return extra.Marshal(limits(l), l.Extra)
 }
@@ -4,6 +4,8 @@ package messages
 import (
 	"fmt"
 	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 )
 
 // MessageType is the generic type of a message
@@ -24,6 +26,20 @@ type Message struct {
 	Type MessageType `json:"type"`
 	// Content of this message
 	Content string `json:"content"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type message Message
+	return extra.Unmarshal(data, (*message)(m), &m.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (m Message) MarshalJSON() ([]byte, error) {
+	type message Message
+	return extra.Marshal(message(m), m.Extra)
 }
 
 // String converts this message to a RepRapFirmware-style message
@@ -2,150 +2,205 @@
 package move
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 
-	"github.com/mitchellh/mapstructure"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 )
 
-// Kinematics is a placeholder type for SerDe
-type Kinematics map[string]interface{}
+// KinematicsVariant is implemented by all concrete kinematics types:
+// *BaseKinematics, *CoreKinematics, *DeltaKinematics, *HangprinterKinematics,
+// *ScaraKinematics and *RawKinematics
+type KinematicsVariant interface {
+	// GetName returns the name of the kinematics
+	GetName() KinematicsName
+	// baseKinematics returns the common properties
+	baseKinematics() *BaseKinematics
+}
+
+// Kinematics holds the currently configured kinematics. When it is decoded Value is
+// set to the concrete type matching the name member so it can be accessed using a
+// type switch. Names that are not known to this package result in *RawKinematics.
+type Kinematics struct {
+	// Value is the concrete kinematics or nil if there is none.
+	// Members unknown to it are retained in its Extra field.
+	Value KinematicsVariant `json:"-"`
+}
+
+// newKinematicsVariant creates an instance of the concrete type for the given name
+func newKinematicsVariant(name KinematicsName) KinematicsVariant {
+	switch name {
+	case Cartesian, CoreXY, CoreXYU, CoreXYUV, CoreXZ, MarkForged:
+		return &CoreKinematics{
+			ForwardMatrix: DefaultForwardMatrix(),
+			InverseMatrix: DefaultInverseMatrix(),
+		}
+	case Delta, RotaryDelta:
+		return &DeltaKinematics{}
+	case Hangprinter:
+		return &HangprinterKinematics{
+			AnchorA:     DefaultAnchorA(),
+			AnchorB:     DefaultAnchorB(),
+			AnchorC:     DefaultAnchorC(),
+			AnchorDz:    DefaultAnchorDz,
+			PrintRadius: DefaultHangprinterPrintRadius,
+		}
+	case FiveBarScara, Scara:
+		return &ScaraKinematics{}
+	case Polar, Unknown:
+		return &BaseKinematics{}
+	default:
+		return &RawKinematics{}
+	}
+}
 
 func toKinematics(src interface{}) (Kinematics, error) {
+	var k Kinematics
 	b, err := json.Marshal(src)
 	if err != nil {
-		return nil, err
+		return k, err
 	}
-	var k Kinematics
 	err = json.Unmarshal(b, &k)
-	if err != nil {
-		return nil, err
+	return k, err
+}
+
+// UnmarshalJSON decodes the concrete kinematics. Patches without a different
+// name are applied to the current value.
+func (k *Kinematics) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		k.Value = nil
+		return nil
+	}
+	var head BaseKinematics
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	if k.Value == nil || (head.Name != "" && head.Name != k.Value.GetName()) {
+		k.Value = newKinematicsVariant(head.Name)
+	}
+	if rk, ok := k.Value.(*RawKinematics); ok {
+		merged, err := extra.Merge(rk.Data, data)
+		if err != nil {
+			return err
+		}
+		rk.Data = merged
+		return json.Unmarshal(merged, &rk.BaseKinematics)
+	}
+	return json.Unmarshal(data, k.Value)
+}
+
+// MarshalJSON encodes the concrete kinematics
+func (k Kinematics) MarshalJSON() ([]byte, error) {
+	switch v := k.Value.(type) {
+	case nil:
+		return []byte("null"), nil
+	case *RawKinematics:
+		if len(v.Data) > 0 {
+			return v.Data, nil
+		}
 	}
-	return k, nil
+	return json.Marshal(k.Value)
+}
+
+// VariantValue returns the concrete kinematics so that paths can be resolved against it
+func (k Kinematics) VariantValue() interface{} {
+	return k.Value
 }
 
-// GetName returns the KinematicsName of this Kinematics instance
+// GetName returns the KinematicsName of this Kinematics instance or an empty
+// string if there is none
 func (k Kinematics) GetName() KinematicsName {
-	return KinematicsName(k["name"].(string))
+	if k.Value == nil {
+		return ""
+	}
+	return k.Value.GetName()
 }
 
-// AsBaseKinematics returns this instance as BaseKinematics
+// AsBaseKinematics returns the common properties of this instance
 func (k Kinematics) AsBaseKinematics() (*BaseKinematics, error) {
-	bk := &BaseKinematics{}
-	err := mapstructure.Decode(k, bk)
-	if err != nil {
-		return nil, err
+	if k.Value == nil {
+		return nil, errors.New("No kinematics")
 	}
-	return bk, nil
+	return k.Value.baseKinematics(), nil
 }
 
 // AsZLeadscrewKinematics returns this instance as ZLeadscrewKinematics
 func (k Kinematics) AsZLeadscrewKinematics() (*ZLeadscrewKinematics, error) {
-	zk := &ZLeadscrewKinematics{}
-	err := mapstructure.Decode(k, zk)
-	if err != nil {
-		return nil, err
+	switch v := k.Value.(type) {
+	case *CoreKinematics:
+		return &v.ZLeadscrewKinematics, nil
+	case *ScaraKinematics:
+		return (*ZLeadscrewKinematics)(v), nil
 	}
-	return zk, nil
+	return nil, fmt.Errorf("Not Z leadscrew kinematics: %s", k.GetName())
 }
 
 // AsCoreKinematics returns this instance as CoreKinematics
 func (k Kinematics) AsCoreKinematics() (*CoreKinematics, error) {
-	name := k.GetName()
-	switch name {
-	case Cartesian:
-	case CoreXY:
-	case CoreXYU:
-	case CoreXYUV:
-	case CoreXZ:
-	case MarkForged:
-	default:
-		return nil, fmt.Errorf("Not core kinematics: %s", name)
-	}
-	ck := &CoreKinematics{
-		ForwardMatrix: DefaultForwardMatrix(),
-		InverseMatrix: DefaultInverseMatrix(),
-	}
-	err := mapstructure.Decode(k, ck)
-	if err != nil {
-		return nil, err
+	if ck, ok := k.Value.(*CoreKinematics); ok {
+		return ck, nil
 	}
-
-	return ck, nil
+	return nil, fmt.Errorf("Not core kinematics: %s", k.GetName())
 }
 
 // AsDeltaKinematics returns this instance as DeltaKinematics
 func (k Kinematics) AsDeltaKinematics() (*DeltaKinematics, error) {
-	name := k.GetName()
-	switch name {
-	case Delta:
-	case RotaryDelta:
-	default:
-		return nil, fmt.Errorf("Not delta kinematics: %s", name)
-	}
-
-	dk := &DeltaKinematics{}
-	err := mapstructure.Decode(k, dk)
-	if err != nil {
-		return nil, err
+	if dk, ok := k.Value.(*DeltaKinematics); ok {
+		return dk, nil
 	}
-
-	return dk, nil
+	return nil, fmt.Errorf("Not delta kinematics: %s", k.GetName())
 }
 
 // AsHangprinterKinematics returns this instance as HangprinterKinematics
 func (k Kinematics) AsHangprinterKinematics() (*HangprinterKinematics, error) {
-	name := k.GetName()
-	switch name {
-	case Hangprinter:
-	default:
-		return nil, fmt.Errorf("Not Hangprinter kinematics: %s", name)
-	}
-
-	hk := &HangprinterKinematics{
-		AnchorA:     DefaultAnchorA(),
-		AnchorB:     DefaultAnchorB(),
-		AnchorC:     DefaultAnchorC(),
-		AnchorDz:    DefaultAnchorDz,
-		PrintRadius: DefaultHangprinterPrintRadius,
+	if hk, ok := k.Value.(*HangprinterKinematics); ok {
+		return hk, nil
 	}
-	err := mapstructure.Decode(k, hk)
-	if err != nil {
-		return nil, err
-	}
-
-	return hk, nil
+	return nil, fmt.Errorf("Not Hangprinter kinematics: %s", k.GetName())
 }
 
 // AsScaraKinematics returns this instance as ScaraKinematics
 func (k Kinematics) AsScaraKinematics() (*ScaraKinematics, error) {
-	name := k.GetName()
-	switch name {
-	case FiveBarScara:
-	case Scara:
-	default:
-		return nil, fmt.Errorf("Not Scara kinematics: %s", name)
+	if sk, ok := k.Value.(*ScaraKinematics); ok {
+		return sk, nil
 	}
-
-	sk := &ScaraKinematics{}
-	err := mapstructure.Decode(k, sk)
-	if err != nil {
-		return nil, err
-	}
-	return sk, nil
+	return nil, fmt.Errorf("Not Scara kinematics: %s", k.GetName())
 }
 
 // BaseKinematics holds information about the configured kinematics
 type BaseKinematics struct {
 	// Name of currently configured kinematics
 	Name KinematicsName `json:"name"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (bk *BaseKinematics) UnmarshalJSON(data []byte) error {
+	type baseKinematics BaseKinematics
+	return extra.Unmarshal(data, (*baseKinematics)(bk), &bk.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (bk BaseKinematics) MarshalJSON() ([]byte, error) {
+	type baseKinematics BaseKinematics
+	return extra.Marshal(baseKinematics(bk), bk.Extra)
+}
+
+// GetName returns the name of the kinematics
+func (bk *BaseKinematics) GetName() KinematicsName {
+	return bk.Name
+}
+
+func (bk *BaseKinematics) baseKinematics() *BaseKinematics {
+	return bk
 }
 
 // AsKinematics converts this instance to Kinematics type
 func (bk *BaseKinematics) AsKinematics() Kinematics {
-	return Kinematics{
-		"name": bk.Name,
-	}
+	return Kinematics{Value: bk}
 }
 
 // TiltCorrection parameters for Z leadscrew compensation
@@ -162,14 +217,42 @@ type TiltCorrection struct {
 	ScrewX []float64 `json:"screwX"`
 	// ScrewY are the Y coordinates of the leadscrews in mm
 	ScrewY []float64 `json:"screwY"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (tc *TiltCorrection) UnmarshalJSON(data []byte) error {
+	type tiltCorrection TiltCorrection
+	return extra.Unmarshal(data, (*tiltCorrection)(tc), &tc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (tc TiltCorrection) MarshalJSON() ([]byte, error) {
+	type tiltCorrection TiltCorrection
+	return extra.Marshal(tiltCorrection(tc), tc.Extra)
 }
 
 // ZLeadscrewKinematics is the base kinematics type that provides the ability
 // to level the bed using Z leadscrews
 type ZLeadscrewKinematics struct {
-	BaseKinematics `mapstructure:",squash"`
+	BaseKinematics
 	// TiltCorrection are the parameters describing the tilt correction
 	TiltCorrection TiltCorrection `json:"tiltCorrection"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (zk *ZLeadscrewKinematics) UnmarshalJSON(data []byte) error {
+	type zLeadscrewKinematics ZLeadscrewKinematics
+	return extra.Unmarshal(data, (*zLeadscrewKinematics)(zk), &zk.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (zk ZLeadscrewKinematics) MarshalJSON() ([]byte, error) {
+	type zLeadscrewKinematics ZLeadscrewKinematics
+	return extra.Marshal(zLeadscrewKinematics(zk), zk.Extra)
 }
 
 // AsKinematics converts this instance to Kinematics type
@@ -197,21 +280,35 @@ func DefaultInverseMatrix() [][]float64 {
 
 // CoreKinematics holds information about core kinematics
 type CoreKinematics struct {
-	ZLeadscrewKinematics `mapstructure:",squash"`
+	ZLeadscrewKinematics
 	// ForwardMatrix is the regular movement matrix
 	ForwardMatrix [][]float64 `json:"forwardMatrix"`
 	// InverseMatrix is the inverted movement matrix
 	InverseMatrix [][]float64 `json:"inverseMatrix"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (ck *CoreKinematics) UnmarshalJSON(data []byte) error {
+	type coreKinematics CoreKinematics
+	return extra.Unmarshal(data, (*coreKinematics)(ck), &ck.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (ck CoreKinematics) MarshalJSON() ([]byte, error) {
+	type coreKinematics CoreKinematics
+	return extra.Marshal(coreKinematics(ck), ck.Extra)
 }
 
 // AsKinematics converts this instance to Kinematics type
 func (ck *CoreKinematics) AsKinematics() (Kinematics, error) {
-	return toKinematics(ck)
+	return Kinematics{Value: ck}, nil
 }
 
 // DeltaKinematics holds information about delta kinematics
 type DeltaKinematics struct {
-	BaseKinematics `mapstructure:",squash"`
+	BaseKinematics
 	// DeltaRadius in mm
 	DeltaRadius float64 `json:"deltaRadius"`
 	// HomedHeight in mm
@@ -226,11 +323,25 @@ type DeltaKinematics struct {
 	// YTilt is how much Z needs to be raised for each unit of movement
 	// in the +Y direction
 	YTilt float64 `json:"yTilt"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (dk *DeltaKinematics) UnmarshalJSON(data []byte) error {
+	type deltaKinematics DeltaKinematics
+	return extra.Unmarshal(data, (*deltaKinematics)(dk), &dk.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (dk DeltaKinematics) MarshalJSON() ([]byte, error) {
+	type deltaKinematics DeltaKinematics
+	return extra.Marshal(deltaKinematics(dk), dk.Extra)
 }
 
 // AsKinematics converts this instance to Kinematics type
 func (dk *DeltaKinematics) AsKinematics() (Kinematics, error) {
-	return toKinematics(dk)
+	return Kinematics{Value: dk}, nil
 }
 
 // DeltaTower properties
@@ -245,6 +356,20 @@ type DeltaTower struct {
 	XPos float64 `json:"xPos"`
 	// YPos is the Y coordinate of this tower (in mm)
 	YPos float64 `json:"yPos"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (dt *DeltaTower) UnmarshalJSON(data []byte) error {
+	type deltaTower DeltaTower
+	return extra.Unmarshal(data, (*deltaTower)(dt), &dt.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (dt DeltaTower) MarshalJSON() ([]byte, error) {
+	type deltaTower DeltaTower
+	return extra.Marshal(deltaTower(dt), dt.Extra)
 }
 
 const (
@@ -265,7 +390,7 @@ func DefaultAnchorC() []float64 { return []float64{-2000, 1000, -100} }
 
 // HangprinterKinematics properties
 type HangprinterKinematics struct {
-	BaseKinematics `mapstructure:",squash"`
+	BaseKinematics
 	// AnchorA of the hangprinter
 	AnchorA []float64 `json:"anchorA"`
 	// AnchorB of the hangprinter
@@ -276,16 +401,59 @@ type HangprinterKinematics struct {
 	AnchorDz float64 `json:"anchorDz"`
 	// PrintRadius in mm
 	PrintRadius float64 `json:"printRadius"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (hk *HangprinterKinematics) UnmarshalJSON(data []byte) error {
+	type hangprinterKinematics HangprinterKinematics
+	return extra.Unmarshal(data, (*hangprinterKinematics)(hk), &hk.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (hk HangprinterKinematics) MarshalJSON() ([]byte, error) {
+	type hangprinterKinematics HangprinterKinematics
+	return extra.Marshal(hangprinterKinematics(hk), hk.Extra)
 }
 
 // AsKinematics converts this instance to Kinematics type
 func (hk *HangprinterKinematics) AsKinematics() (Kinematics, error) {
-	return toKinematics(hk)
+	return Kinematics{Value: hk}, nil
 }
 
 // ScaraKinematics is the type for SCARA Kinematics
 type ScaraKinematics ZLeadscrewKinematics
 
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (sk *ScaraKinematics) UnmarshalJSON(data []byte) error {
+	type scaraKinematics ScaraKinematics
+	return extra.Unmarshal(data, (*scaraKinematics)(sk), &sk.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (sk ScaraKinematics) MarshalJSON() ([]byte, error) {
+	type scaraKinematics ScaraKinematics
+	return extra.Marshal(scaraKinematics(sk), sk.Extra)
+}
+
+// AsKinematics converts this instance to Kinematics type
+func (sk *ScaraKinematics) AsKinematics() (Kinematics, error) {
+	return Kinematics{Value: sk}, nil
+}
+
+// RawKinematics holds kinematics with a name that is not known to this package
+type RawKinematics struct {
+	BaseKinematics
+	// Data is the complete JSON object which is written back unchanged
+	Data json.RawMessage `json:"-"`
+}
+
+// AsKinematics converts this instance to Kinematics type
+func (rk *RawKinematics) AsKinematics() (Kinematics, error) {
+	return Kinematics{Value: rk}, nil
+}
+
 // KinematicsName represents the supported kinmatics types
 type KinematicsName string
 
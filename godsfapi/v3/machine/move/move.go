@@ -1,7 +1,10 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package move
 
-import "github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+import (
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
 
 // Move holds information about the move subsystem
 type Move struct {
@@ -18,7 +21,7 @@ type Move struct {
 	// Idle current reduction parameters
 	Idle MotorsIdleControl `json:"idle"`
 	// Kinematics holds information about the currently configured kinematics
-	// Use one of the NewXXKinematics methods to convert it
+	// Use a type switch on Kinematics.Value to access the concrete type
 	Kinematics Kinematics `json:"kinematics"`
 	// PrintingAcceleration is maximum accelertion allowed while printing (in mm/s^2)
 	PrintingAcceleration float64 `json:"printingAcceleration"`
@@ -34,6 +37,20 @@ type Move struct {
 	VirtualPos float64 `json:"virtualPos"`
 	// WorkspaceNumber is the index of the currently selected workplace (0..8)
 	WorkplaceNumber int `json:"workplaceNumber"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (m *Move) UnmarshalJSON(data []byte) error {
+	type move Move
+	return extra.Unmarshal(data, (*move)(m), &m.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (m Move) MarshalJSON() ([]byte, error) {
+	type move Move
+	return extra.Marshal(move(m), m.Extra)
 }
 
 // MoveCalibration holds information about configured calibration options
@@ -44,6 +61,20 @@ type MoveCalibration struct {
 	Initial MoveDeviations `json:"initial"`
 	// NumFactors is the number of factors used (for Delta calibration)
 	NumFactors int64 `json:"numFactors"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mc *MoveCalibration) UnmarshalJSON(data []byte) error {
+	type moveCalibration MoveCalibration
+	return extra.Unmarshal(data, (*moveCalibration)(mc), &mc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mc MoveCalibration) MarshalJSON() ([]byte, error) {
+	type moveCalibration MoveCalibration
+	return extra.Marshal(moveCalibration(mc), mc.Extra)
 }
 
 // MoveCompensation holds informatin about the configured compensation options
@@ -60,6 +91,20 @@ type MoveCompensation struct {
 	Skew Skew
 	// Type is the type of compensation in use
 	Type MoveCompensationType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mc *MoveCompensation) UnmarshalJSON(data []byte) error {
+	type moveCompensation MoveCompensation
+	return extra.Unmarshal(data, (*moveCompensation)(mc), &mc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mc MoveCompensation) MarshalJSON() ([]byte, error) {
+	type moveCompensation MoveCompensation
+	return extra.Marshal(moveCompensation(mc), mc.Extra)
 }
 
 // MoveCompensationType are the supported compensation types
@@ -78,6 +123,20 @@ type MoveDeviations struct {
 	Deviation float64 `json:"deviation"`
 	// Mean deviation (in mm)
 	Mean float64 `json:"mean"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (md *MoveDeviations) UnmarshalJSON(data []byte) error {
+	type moveDeviations MoveDeviations
+	return extra.Unmarshal(data, (*moveDeviations)(md), &md.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (md MoveDeviations) MarshalJSON() ([]byte, error) {
+	type moveDeviations MoveDeviations
+	return extra.Marshal(moveDeviations(md), md.Extra)
 }
 
 const (
@@ -96,6 +155,20 @@ type MoveInputShaping struct {
 	MinimumAcceleration float64 `json:"minimumAcceleration"`
 	// Type of configured input shaping
 	Type MoveInputShapingType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mis *MoveInputShaping) UnmarshalJSON(data []byte) error {
+	type moveInputShaping MoveInputShaping
+	return extra.Unmarshal(data, (*moveInputShaping)(mis), &mis.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mis MoveInputShaping) MarshalJSON() ([]byte, error) {
+	type moveInputShaping MoveInputShaping
+	return extra.Marshal(moveInputShaping(mis), mis.Extra)
 }
 
 // MoveInputShapingType are the possible input shaping methods
@@ -115,6 +188,20 @@ type MoveQueueItem struct {
 	GracePeriod uint64 `json:"gracePeriod"`
 	// Length is the maximum number of moves that can be accomodated in the DDA ring
 	Length int64 `json:"length"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mqi *MoveQueueItem) UnmarshalJSON(data []byte) error {
+	type moveQueueItem MoveQueueItem
+	return extra.Unmarshal(data, (*moveQueueItem)(mqi), &mqi.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mqi MoveQueueItem) MarshalJSON() ([]byte, error) {
+	type moveQueueItem MoveQueueItem
+	return extra.Marshal(moveQueueItem(mqi), mqi.Extra)
 }
 
 // Default values for Axis
@@ -162,6 +249,20 @@ type Axis struct {
 	Visible bool `json:"visible"`
 	// WorkplaceOffsets for this axis (in mm)
 	WorkplaceOffsets []float64 `json:"workplaceOffsets"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (a *Axis) UnmarshalJSON(data []byte) error {
+	type axis Axis
+	return extra.Unmarshal(data, (*axis)(a), &a.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (a Axis) MarshalJSON() ([]byte, error) {
+	type axis Axis
+	return extra.Marshal(axis(a), a.Extra)
 }
 
 // CurrentMove holds information about the current move
@@ -176,6 +277,20 @@ type CurrentMove struct {
 	RequestedSpeed float64 `json:"requestedSpeed"`
 	// TopSpeed actually reached for the current move (in mm/s)
 	TopSpeed float64 `json:"topSpeed"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (cm *CurrentMove) UnmarshalJSON(data []byte) error {
+	type currentMove CurrentMove
+	return extra.Unmarshal(data, (*currentMove)(cm), &cm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (cm CurrentMove) MarshalJSON() ([]byte, error) {
+	type currentMove CurrentMove
+	return extra.Marshal(currentMove(cm), cm.Extra)
 }
 
 // Default values for Extruder
@@ -212,6 +327,20 @@ type Extruder struct {
 	Speed float64 `json:"speed"`
 	// StepsPerMm for this extruder
 	StepsPerMm float64 `json:"stepsPerMm"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (e *Extruder) UnmarshalJSON(data []byte) error {
+	type extruder Extruder
+	return extra.Unmarshal(data, (*extruder)(e), &e.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (e Extruder) MarshalJSON() ([]byte, error) {
+	type extruder Extruder
+	return extra.Marshal(extruder(e), e.Extra)
 }
 
 // ExtruderNonLinear contains non-linear extrusion parameters (see M592)
@@ -222,6 +351,20 @@ type ExtruderNonLinear struct {
 	B float64 `json:"b"`
 	// UpperLimit of the nonlinear extrusion compensation
 	UpperLimit float64 `json:"upperLimit"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (enl *ExtruderNonLinear) UnmarshalJSON(data []byte) error {
+	type extruderNonLinear ExtruderNonLinear
+	return extra.Unmarshal(data, (*extruderNonLinear)(enl), &enl.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (enl ExtruderNonLinear) MarshalJSON() ([]byte, error) {
+	type extruderNonLinear ExtruderNonLinear
+	return extra.Marshal(extruderNonLinear(enl), enl.Extra)
 }
 
 // Microstepping holds information about configured microstepping
@@ -230,6 +373,20 @@ type Microstepping struct {
 	Interpolated bool `json:"interpolated"`
 	// Value is the microstepping factor
 	Value uint16 `json:"value"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (m *Microstepping) UnmarshalJSON(data []byte) error {
+	type microstepping Microstepping
+	return extra.Unmarshal(data, (*microstepping)(m), &m.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (m Microstepping) MarshalJSON() ([]byte, error) {
+	type microstepping Microstepping
+	return extra.Marshal(microstepping(m), m.Extra)
 }
 
 // MotorsIdleControl holds idle factor parameters for automatic MotorsIdleControl
@@ -239,6 +396,20 @@ type MotorsIdleControl struct {
 	Timeout float64 `json:"timeout"`
 	// Factor of the reduction on a scale between 0 and 1
 	Factor float64 `json:"factor"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mic *MotorsIdleControl) UnmarshalJSON(data []byte) error {
+	type motorsIdleControl MotorsIdleControl
+	return extra.Unmarshal(data, (*motorsIdleControl)(mic), &mic.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mic MotorsIdleControl) MarshalJSON() ([]byte, error) {
+	type motorsIdleControl MotorsIdleControl
+	return extra.Marshal(motorsIdleControl(mic), mic.Extra)
 }
 
 // ProbeGrid holds information about the configured probe grid (see M557)
@@ -253,6 +424,20 @@ type ProbeGrid struct {
 	Radius float64 `json:"radius"`
 	// Spacings between coordinates
 	Spacings []float64 `json:"spacings"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pg *ProbeGrid) UnmarshalJSON(data []byte) error {
+	type probeGrid ProbeGrid
+	return extra.Unmarshal(data, (*probeGrid)(pg), &pg.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pg ProbeGrid) MarshalJSON() ([]byte, error) {
+	type probeGrid ProbeGrid
+	return extra.Marshal(probeGrid(pg), pg.Extra)
 }
 
 // Skew holds details about orthogonal axis compensation parameters
@@ -265,4 +450,18 @@ type Skew struct {
 	TanXZ float64 `json:"tanXZ"`
 	// TaxYZ is the tangent of the skew angle for YZ axes
 	TanYZ float64 `json:"tanYZ"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (s *Skew) UnmarshalJSON(data []byte) error {
+	type skew Skew
+	return extra.Unmarshal(data, (*skew)(s), &s.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (s Skew) MarshalJSON() ([]byte, error) {
+	type skew Skew
+	return extra.Marshal(skew(s), s.Extra)
 }
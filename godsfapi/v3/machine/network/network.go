@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package network
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 const (
 	// DefaultName of the machine
 	DefaultName = "My Duet"
@@ -48,6 +50,20 @@ type Network struct {
 	Interfaces []NetworkInterface `json:"interfaces"`
 	// Name of the machine
 	Name string `json:"name"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (n *Network) UnmarshalJSON(data []byte) error {
+	type network Network
+	return extra.Unmarshal(data, (*network)(n), &n.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (n Network) MarshalJSON() ([]byte, error) {
+	type network Network
+	return extra.Marshal(network(n), n.Extra)
 }
 
 // NetworkInterface holds information about a network interface
@@ -77,4 +93,18 @@ type NetworkInterface struct {
 	Subnet string `json:"subnet"`
 	// Type of this network interface
 	Type InterfaceType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (ni *NetworkInterface) UnmarshalJSON(data []byte) error {
+	type networkInterface NetworkInterface
+	return extra.Unmarshal(data, (*networkInterface)(ni), &ni.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (ni NetworkInterface) MarshalJSON() ([]byte, error) {
+	type networkInterface NetworkInterface
+	return extra.Marshal(networkInterface(ni), ni.Extra)
 }
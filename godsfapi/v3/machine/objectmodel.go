@@ -2,8 +2,11 @@
 package machine
 
 import (
+	"encoding/json"
+
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/boards"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/directories"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/fans"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/httpendpoints"
@@ -51,7 +54,7 @@ type MachineModel struct {
 	// Network holds information about connected network adapters
 	Network network.Network `json:"network"`
 	// Plugins is the map of loaded SBC plugins where each key is the plugin identifier
-	Plugins map[string]plugins.Plugin
+	Plugins map[string]plugins.Plugin `json:"plugins"`
 	// Scanner holds information about the 3D scanner subsystem
 	Scanner scanner.Scanner `json:"scanner"`
 	// Sensors holds information about connected sensors including Z-probes and endstops
@@ -66,12 +69,34 @@ type MachineModel struct {
 	UserSessions []usersessions.UserSession `json:"userSessions"`
 	// UserVariables is a list of user-defined variables
 	// Deprecated: Do not use this field. This will probably be changed to a map in the future.
-	UserVariables []uservariables.UserVariable `json:"-"`
+	UserVariables []uservariables.UserVariable `json:"userVariables"`
 	// Volumes holds a list of available mass storages
 	Volumes []volume.Volume `json:"volumes"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mm *MachineModel) UnmarshalJSON(data []byte) error {
+	type machineModel MachineModel
+	return extra.Unmarshal(data, (*machineModel)(mm), &mm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mm MachineModel) MarshalJSON() ([]byte, error) {
+	type machineModel MachineModel
+	return extra.Marshal(machineModel(mm), mm.Extra)
 }
 
 // NewMachineModel creates a new MachineModel
 func NewMachineModel() *MachineModel {
 	return &MachineModel{}
 }
+
+// UpdateFromJson applies a (partial) object model update as received in
+// initmessages.SubscriptionModePatch to this instance.
+// Objects are merged recursively and lists are merged item by item where the
+// length of the patched list defines the new length.
+func (mm *MachineModel) UpdateFromJson(patch []byte) error {
+	return json.Unmarshal(patch, mm)
+}
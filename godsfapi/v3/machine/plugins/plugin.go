@@ -5,6 +5,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
 )
 
@@ -19,6 +20,20 @@ type Plugin struct {
 	// Pid is the process ID of the plugin or -1 if not started
 	// It is set to 0 while the plugin is being shut down
 	Pid int64 `json:"pid"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (p *Plugin) UnmarshalJSON(data []byte) error {
+	type plugin Plugin
+	return extra.Unmarshal(data, (*plugin)(p), &p.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (p Plugin) MarshalJSON() ([]byte, error) {
+	type plugin Plugin
+	return extra.Marshal(plugin(p), p.Extra)
 }
 
 // PluginManifest holds information about the third-party plugin
@@ -69,6 +84,20 @@ type PluginManifest struct {
 	// (DSF/DWC in SBC mode - or - DWC in standalone mode).
 	// Before commands.SetPluginData can be used, corresponding properties must be registered via this property first!
 	Data map[string]string `json:"data"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pm *PluginManifest) UnmarshalJSON(data []byte) error {
+	type pluginManifest PluginManifest
+	return extra.Unmarshal(data, (*pluginManifest)(pm), &pm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pm PluginManifest) MarshalJSON() ([]byte, error) {
+	type pluginManifest PluginManifest
+	return extra.Marshal(pluginManifest(pm), pm.Extra)
 }
 
 // CheckVersion checks if the given version satisfies a required version
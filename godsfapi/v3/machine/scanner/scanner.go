@@ -1,12 +1,28 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package scanner
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Scanner holds information about the 3D scanner subsytem
 type Scanner struct {
 	// Progress of the current action on scale between 0 and 1
 	Progress float64 `json:"progress"`
 	// Status of the 3D scanner
 	Status ScannerStatus `json:"status"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (s *Scanner) UnmarshalJSON(data []byte) error {
+	type scanner Scanner
+	return extra.Unmarshal(data, (*scanner)(s), &s.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (s Scanner) MarshalJSON() ([]byte, error) {
+	type scanner Scanner
+	return extra.Marshal(scanner(s), s.Extra)
 }
 
 // ScannerStatus represents possible states of an attached 3D scanner
@@ -2,32 +2,101 @@
 package sensors
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 
-	"github.com/mitchellh/mapstructure"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
 )
 
-// FilamentMonitor holds information about a filament monitor
-type FilamentMonitor map[string]interface{}
+// FilamentMonitorVariant is implemented by all concrete filament monitor types:
+// *BaseFilamentMonitor, *SimpleFilamentMonitor, *LaserFilamentMonitor,
+// *PulsedFilamentMonitor, *RotatingMagnetFilamentMonitor and *RawFilamentMonitor
+type FilamentMonitorVariant interface {
+	// GetType returns the type of the filament monitor
+	GetType() FilamentMonitorType
+	// baseFilamentMonitor returns the common properties
+	baseFilamentMonitor() *BaseFilamentMonitor
+}
 
-func toFilamentMonitor(src interface{}) (FilamentMonitor, error) {
-	b, err := json.Marshal(src)
-	if err != nil {
-		return nil, err
+// FilamentMonitor holds information about a filament monitor. When it is decoded
+// Value is set to the concrete type matching the type member so it can be accessed
+// using a type switch. Unknown types result in *RawFilamentMonitor.
+type FilamentMonitor struct {
+	// Value is the concrete filament monitor or nil if there is none.
+	// Members unknown to it are retained in its Extra field.
+	Value FilamentMonitorVariant `json:"-"`
+}
+
+// newFilamentMonitorVariant creates an instance of the concrete type for the given type
+func newFilamentMonitorVariant(t FilamentMonitorType) FilamentMonitorVariant {
+	switch t {
+	case Simple:
+		return &SimpleFilamentMonitor{}
+	case Laser:
+		return &LaserFilamentMonitor{}
+	case Pulsed:
+		return &PulsedFilamentMonitor{}
+	case RotatingMagnet:
+		return &RotatingMagnetFilamentMonitor{}
+	case Unkown:
+		return &BaseFilamentMonitor{}
+	default:
+		return &RawFilamentMonitor{}
 	}
-	var fm FilamentMonitor
-	err = json.Unmarshal(b, &fm)
-	if err != nil {
-		return nil, err
+}
+
+// UnmarshalJSON decodes the concrete filament monitor. Patches without a different
+// type are applied to the current value.
+func (f *FilamentMonitor) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Value = nil
+		return nil
+	}
+	var head BaseFilamentMonitor
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
 	}
-	return fm, nil
+	if f.Value == nil || (head.Type != "" && head.Type != f.Value.GetType()) {
+		f.Value = newFilamentMonitorVariant(head.Type)
+	}
+	if rfm, ok := f.Value.(*RawFilamentMonitor); ok {
+		merged, err := extra.Merge(rfm.Data, data)
+		if err != nil {
+			return err
+		}
+		rfm.Data = merged
+		return json.Unmarshal(merged, &rfm.BaseFilamentMonitor)
+	}
+	return json.Unmarshal(data, f.Value)
 }
 
-// GetType returns the FilamentMonitorType of this instance
+// MarshalJSON encodes the concrete filament monitor
+func (f FilamentMonitor) MarshalJSON() ([]byte, error) {
+	switch v := f.Value.(type) {
+	case nil:
+		return []byte("null"), nil
+	case *RawFilamentMonitor:
+		if len(v.Data) > 0 {
+			return v.Data, nil
+		}
+	}
+	return json.Marshal(f.Value)
+}
+
+// VariantValue returns the concrete filament monitor so that paths can be resolved against it
+func (f FilamentMonitor) VariantValue() interface{} {
+	return f.Value
+}
+
+// GetType returns the FilamentMonitorType of this instance or an empty string
+// if there is none
 func (f FilamentMonitor) GetType() FilamentMonitorType {
-	return FilamentMonitorType(f["type"].(string))
+	if f.Value == nil {
+		return ""
+	}
+	return f.Value.GetType()
 }
 
 // FilamentMonitors is a slice of FilamentMonitor
@@ -36,90 +105,72 @@ type FilamentMonitors []FilamentMonitor
 // ErrInvalidIndex is returned in case an invalid index is accessed
 var ErrInvalidIndex = errors.New("Invalid index")
 
-// GetAsBaseFilamentMonitor returns the instance at the given index as BaseFilamentMonitor
-func (fm FilamentMonitors) GetAsBaseFilamentMonitor(i int) (*BaseFilamentMonitor, error) {
-	if i < 0 || i > len(fm) {
-		return nil, ErrInvalidIndex
+// get returns the filament monitor at the given index
+func (fm FilamentMonitors) get(i int) (FilamentMonitor, error) {
+	if i < 0 || i >= len(fm) {
+		return FilamentMonitor{}, ErrInvalidIndex
 	}
-	f := fm[i]
-	bfm := &BaseFilamentMonitor{}
-	err := mapstructure.Decode(f, bfm)
+	return fm[i], nil
+}
+
+// GetAsBaseFilamentMonitor returns the common properties of the instance at the given index
+func (fm FilamentMonitors) GetAsBaseFilamentMonitor(i int) (*BaseFilamentMonitor, error) {
+	f, err := fm.get(i)
 	if err != nil {
 		return nil, err
 	}
-	return bfm, nil
+	if f.Value == nil {
+		return nil, fmt.Errorf("No filament monitor at index %d", i)
+	}
+	return f.Value.baseFilamentMonitor(), nil
 }
 
 // GetAsSimpleFilamentMonitor returns the instance at the given index as SimpleFilamentMonitor
 func (fm FilamentMonitors) GetAsSimpleFilamentMonitor(i int) (*SimpleFilamentMonitor, error) {
-	if i < 0 || i > len(fm) {
-		return nil, ErrInvalidIndex
-	}
-	f := fm[i]
-	name := f.GetType()
-	if name != Simple {
-		return nil, fmt.Errorf("Not SimpleFilamentMonitor: %s", name)
-	}
-	sfm := &SimpleFilamentMonitor{}
-	err := mapstructure.Decode(f, sfm)
+	f, err := fm.get(i)
 	if err != nil {
 		return nil, err
 	}
-	return sfm, nil
+	if sfm, ok := f.Value.(*SimpleFilamentMonitor); ok {
+		return sfm, nil
+	}
+	return nil, fmt.Errorf("Not SimpleFilamentMonitor: %s", f.GetType())
 }
 
 // GetAsLaserFilamentMonitor returns the instance at the given index as LaserFilamentMonitor
 func (fm FilamentMonitors) GetAsLaserFilamentMonitor(i int) (*LaserFilamentMonitor, error) {
-	if i < 0 || i > len(fm) {
-		return nil, ErrInvalidIndex
-	}
-	f := fm[i]
-	name := f.GetType()
-	if name != Laser {
-		return nil, fmt.Errorf("Not LaserFilamentMonitor: %s", name)
-	}
-	lfm := &LaserFilamentMonitor{}
-	err := mapstructure.Decode(f, lfm)
+	f, err := fm.get(i)
 	if err != nil {
 		return nil, err
 	}
-	return lfm, nil
+	if lfm, ok := f.Value.(*LaserFilamentMonitor); ok {
+		return lfm, nil
+	}
+	return nil, fmt.Errorf("Not LaserFilamentMonitor: %s", f.GetType())
 }
 
 // GetAsPulsedFilamentMonitor returns the instance at the given index as PulsedFilamentMonitor
 func (fm FilamentMonitors) GetAsPulsedFilamentMonitor(i int) (*PulsedFilamentMonitor, error) {
-	if i < 0 || i > len(fm) {
-		return nil, ErrInvalidIndex
-	}
-	f := fm[i]
-	name := f.GetType()
-	if name != Pulsed {
-		return nil, fmt.Errorf("Not PulsedFilamentMonitor: %s", name)
-	}
-	pfm := &PulsedFilamentMonitor{}
-	err := mapstructure.Decode(f, pfm)
+	f, err := fm.get(i)
 	if err != nil {
 		return nil, err
 	}
-	return pfm, nil
+	if pfm, ok := f.Value.(*PulsedFilamentMonitor); ok {
+		return pfm, nil
+	}
+	return nil, fmt.Errorf("Not PulsedFilamentMonitor: %s", f.GetType())
 }
 
 // GetAsRotatingMagnetFilamentMonitor returns the instance at the given index as RotatingMagnetFilamentMonitor
 func (fm FilamentMonitors) GetAsRotatingMagnetFilamentMonitor(i int) (*RotatingMagnetFilamentMonitor, error) {
-	if i < 0 || i > len(fm) {
-		return nil, ErrInvalidIndex
-	}
-	f := fm[i]
-	name := f.GetType()
-	if name != RotatingMagnet {
-		return nil, fmt.Errorf("Not RotatingMagnetFilamentMonitor: %s", name)
-	}
-	rmfm := &RotatingMagnetFilamentMonitor{}
-	err := mapstructure.Decode(f, rmfm)
+	f, err := fm.get(i)
 	if err != nil {
 		return nil, err
 	}
-	return rmfm, nil
+	if rmfm, ok := f.Value.(*RotatingMagnetFilamentMonitor); ok {
+		return rmfm, nil
+	}
+	return nil, fmt.Errorf("Not RotatingMagnetFilamentMonitor: %s", f.GetType())
 }
 
 // FilamentMonitorStatus are the possible filament sensor statuses
@@ -150,24 +201,74 @@ type BaseFilamentMonitor struct {
 	Status FilamentMonitorStatus `json:"status"`
 	// Type of this filament monitor
 	Type FilamentMonitorType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (bfm *BaseFilamentMonitor) UnmarshalJSON(data []byte) error {
+	type baseFilamentMonitor BaseFilamentMonitor
+	return extra.Unmarshal(data, (*baseFilamentMonitor)(bfm), &bfm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (bfm BaseFilamentMonitor) MarshalJSON() ([]byte, error) {
+	type baseFilamentMonitor BaseFilamentMonitor
+	return extra.Marshal(baseFilamentMonitor(bfm), bfm.Extra)
+}
+
+// GetType returns the type of this filament monitor
+func (bfm *BaseFilamentMonitor) GetType() FilamentMonitorType {
+	return bfm.Type
+}
+
+func (bfm *BaseFilamentMonitor) baseFilamentMonitor() *BaseFilamentMonitor {
+	return bfm
 }
 
 // AsFilamentMonitor returns this instance as FilamentMonitor
 func (bfm *BaseFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
-	return toFilamentMonitor(bfm)
+	return FilamentMonitor{Value: bfm}, nil
 }
 
 // SimpleFilamentMonitor represents a simple filament monitor
 type SimpleFilamentMonitor struct {
-	BaseFilamentMonitor `mapstructure:",squash"`
+	BaseFilamentMonitor
 
 	// FilamentPresent indicates if filament is present or nil if not available
 	FilamentPresent *bool `json:"filamentPresent"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (sfm *SimpleFilamentMonitor) UnmarshalJSON(data []byte) error {
+	type simpleFilamentMonitor SimpleFilamentMonitor
+	return extra.Unmarshal(data, (*simpleFilamentMonitor)(sfm), &sfm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (sfm SimpleFilamentMonitor) MarshalJSON() ([]byte, error) {
+	type simpleFilamentMonitor SimpleFilamentMonitor
+	return extra.Marshal(simpleFilamentMonitor(sfm), sfm.Extra)
 }
 
 // AsFilamentMonitor returns this instance as FilamentMonitor
 func (sfm *SimpleFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
-	return toFilamentMonitor(sfm)
+	return FilamentMonitor{Value: sfm}, nil
+}
+
+// RawFilamentMonitor holds a filament monitor of a type that is not known to this package
+type RawFilamentMonitor struct {
+	BaseFilamentMonitor
+
+	// Data is the complete JSON object which is written back unchanged
+	Data json.RawMessage `json:"-"`
+}
+
+// AsFilamentMonitor returns this instance as FilamentMonitor
+func (rfm *RawFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
+	return FilamentMonitor{Value: rfm}, nil
 }
 
 // FilamentMonitorProperties shared by all FilamentMonitorCalibrated and FilamentMonitorConfigured structs
@@ -180,18 +281,46 @@ type FilamentMonitorProperties struct {
 
 // FilamentMonitorCalibrated shared by all concrete type structs
 type FilamentMonitorCalibrated struct {
-	FilamentMonitorProperties `mapstructure:",squash"`
+	FilamentMonitorProperties
 
 	// TotalDistance extruded (in mm)
 	TotalDistance float64 `json:"totalDistance"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (fmc *FilamentMonitorCalibrated) UnmarshalJSON(data []byte) error {
+	type filamentMonitorCalibrated FilamentMonitorCalibrated
+	return extra.Unmarshal(data, (*filamentMonitorCalibrated)(fmc), &fmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (fmc FilamentMonitorCalibrated) MarshalJSON() ([]byte, error) {
+	type filamentMonitorCalibrated FilamentMonitorCalibrated
+	return extra.Marshal(filamentMonitorCalibrated(fmc), fmc.Extra)
 }
 
 // FilamentMonitorConfigured shared by all concrete type structs
 type FilamentMonitorConfigured struct {
-	FilamentMonitorProperties `mapstructure:",squash"`
+	FilamentMonitorProperties
 
 	// SampleDistance in mm
 	SampleDistance float64 `json:"sampleDistance"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (fmc *FilamentMonitorConfigured) UnmarshalJSON(data []byte) error {
+	type filamentMonitorConfigured FilamentMonitorConfigured
+	return extra.Unmarshal(data, (*filamentMonitorConfigured)(fmc), &fmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (fmc FilamentMonitorConfigured) MarshalJSON() ([]byte, error) {
+	type filamentMonitorConfigured FilamentMonitorConfigured
+	return extra.Marshal(filamentMonitorConfigured(fmc), fmc.Extra)
 }
 
 // FilamentMonitorType represents supported filament monitors
@@ -212,87 +341,211 @@ const (
 
 // LaserFilamentMonitor holds information about a laser filament monitor
 type LaserFilamentMonitor struct {
-	SimpleFilamentMonitor `mapstructure:",squash"`
+	SimpleFilamentMonitor
 
 	// Calibrated holds calibrated properties of this filament sensor
 	Calibrated LaserFilamentMonitorCalibrated `json:"calibrated"`
 	// Configured holds configured properties of this filament sensor
 	Configured LaserFilamentMonitorConfigured `json:"configured"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (lfm *LaserFilamentMonitor) UnmarshalJSON(data []byte) error {
+	type laserFilamentMonitor LaserFilamentMonitor
+	return extra.Unmarshal(data, (*laserFilamentMonitor)(lfm), &lfm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (lfm LaserFilamentMonitor) MarshalJSON() ([]byte, error) {
+	type laserFilamentMonitor LaserFilamentMonitor
+	return extra.Marshal(laserFilamentMonitor(lfm), lfm.Extra)
 }
 
 // AsFilamentMonitor returns this instance as FilamentMonitor
 func (lfm *LaserFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
-	return toFilamentMonitor(lfm)
+	return FilamentMonitor{Value: lfm}, nil
 }
 
 // LaserFilamentMonitorCalibrated reprent the calibrated properties
 // of a laser filament monitor
 type LaserFilamentMonitorCalibrated struct {
-	FilamentMonitorCalibrated `mapstructure:",squash"`
+	FilamentMonitorCalibrated
 	// Sensitivity from calibration
 	Sensitivity float64 `json:"sensitivity"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (lfmc *LaserFilamentMonitorCalibrated) UnmarshalJSON(data []byte) error {
+	type laserFilamentMonitorCalibrated LaserFilamentMonitorCalibrated
+	return extra.Unmarshal(data, (*laserFilamentMonitorCalibrated)(lfmc), &lfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (lfmc LaserFilamentMonitorCalibrated) MarshalJSON() ([]byte, error) {
+	type laserFilamentMonitorCalibrated LaserFilamentMonitorCalibrated
+	return extra.Marshal(laserFilamentMonitorCalibrated(lfmc), lfmc.Extra)
 }
 
 // LaserFilamentMonitorConfigured represents configured properties
 // of a laser filament sensor
 type LaserFilamentMonitorConfigured FilamentMonitorConfigured
 
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (lfmc *LaserFilamentMonitorConfigured) UnmarshalJSON(data []byte) error {
+	type laserFilamentMonitorConfigured LaserFilamentMonitorConfigured
+	return extra.Unmarshal(data, (*laserFilamentMonitorConfigured)(lfmc), &lfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (lfmc LaserFilamentMonitorConfigured) MarshalJSON() ([]byte, error) {
+	type laserFilamentMonitorConfigured LaserFilamentMonitorConfigured
+	return extra.Marshal(laserFilamentMonitorConfigured(lfmc), lfmc.Extra)
+}
+
 // PulsedFilamentMonitor holds information about a pulsed filament monitor
 type PulsedFilamentMonitor struct {
-	BaseFilamentMonitor `mapstructure:",squash"`
+	BaseFilamentMonitor
 
 	// Calibrated holds calibrated properties of this filament monitor
 	Calibrated PulsedFilamentMonitorCalibrated `json:"calibrated"`
 	// Configured holds configured properties of this filament monitor
 	Configured PulsedFilamentMonitorConfigured `json:"configured"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pfm *PulsedFilamentMonitor) UnmarshalJSON(data []byte) error {
+	type pulsedFilamentMonitor PulsedFilamentMonitor
+	return extra.Unmarshal(data, (*pulsedFilamentMonitor)(pfm), &pfm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pfm PulsedFilamentMonitor) MarshalJSON() ([]byte, error) {
+	type pulsedFilamentMonitor PulsedFilamentMonitor
+	return extra.Marshal(pulsedFilamentMonitor(pfm), pfm.Extra)
 }
 
 // AsFilamentMonitor returns this instance as FilamentMonitor
 func (pfm *PulsedFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
-	return toFilamentMonitor(pfm)
+	return FilamentMonitor{Value: pfm}, nil
 }
 
 // PulsedFilamentMonitorCalibrated represents calibrated properties of pulsed filament monitor
 type PulsedFilamentMonitorCalibrated struct {
-	FilamentMonitorCalibrated `mapstructure:",squash"`
+	FilamentMonitorCalibrated
 	// MmPerPulse is extruded distance per pulse (in mm)
 	MmPerPulse float64 `json:"mmPerPulse"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pfmc *PulsedFilamentMonitorCalibrated) UnmarshalJSON(data []byte) error {
+	type pulsedFilamentMonitorCalibrated PulsedFilamentMonitorCalibrated
+	return extra.Unmarshal(data, (*pulsedFilamentMonitorCalibrated)(pfmc), &pfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pfmc PulsedFilamentMonitorCalibrated) MarshalJSON() ([]byte, error) {
+	type pulsedFilamentMonitorCalibrated PulsedFilamentMonitorCalibrated
+	return extra.Marshal(pulsedFilamentMonitorCalibrated(pfmc), pfmc.Extra)
 }
 
 // PulsedFilamentMonitorConfigured represents configured properties
 // of a pulsed filament sensor
 type PulsedFilamentMonitorConfigured struct {
-	FilamentMonitorConfigured `mapstructure:",squash"`
+	FilamentMonitorConfigured
 	// MmPerPulse is extruded distance per pulse (in mm)
 	MmPerPulse float64 `json:"mmPerPulse"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (pfmc *PulsedFilamentMonitorConfigured) UnmarshalJSON(data []byte) error {
+	type pulsedFilamentMonitorConfigured PulsedFilamentMonitorConfigured
+	return extra.Unmarshal(data, (*pulsedFilamentMonitorConfigured)(pfmc), &pfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (pfmc PulsedFilamentMonitorConfigured) MarshalJSON() ([]byte, error) {
+	type pulsedFilamentMonitorConfigured PulsedFilamentMonitorConfigured
+	return extra.Marshal(pulsedFilamentMonitorConfigured(pfmc), pfmc.Extra)
 }
 
 // RotatingMagnetFilamentMonitor holds information about a rotating magnet filament monitor
 type RotatingMagnetFilamentMonitor struct {
-	SimpleFilamentMonitor `mapstructure:",squash"`
+	SimpleFilamentMonitor
 
 	// Calibrated holds calibrated properties of this filament monitor
 	Calibrated RotatingMagnetFilamentMonitorCalibrated `json:"calibrated"`
 	// Configured holds configured properties of this filament monitor
 	Configured RotatingMagnetFilamentMonitorConfigured `json:"configured"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (rmfm *RotatingMagnetFilamentMonitor) UnmarshalJSON(data []byte) error {
+	type rotatingMagnetFilamentMonitor RotatingMagnetFilamentMonitor
+	return extra.Unmarshal(data, (*rotatingMagnetFilamentMonitor)(rmfm), &rmfm.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (rmfm RotatingMagnetFilamentMonitor) MarshalJSON() ([]byte, error) {
+	type rotatingMagnetFilamentMonitor RotatingMagnetFilamentMonitor
+	return extra.Marshal(rotatingMagnetFilamentMonitor(rmfm), rmfm.Extra)
 }
 
 // AsFilamentMonitor returns this instance as FilamentMonitor
 func (rmfm *RotatingMagnetFilamentMonitor) AsFilamentMonitor() (FilamentMonitor, error) {
-	return toFilamentMonitor(rmfm)
+	return FilamentMonitor{Value: rmfm}, nil
 }
 
 // RotatingMagnetFilamentMonitorCalibrated represents calibrated properties of pulsed filament monitor
 type RotatingMagnetFilamentMonitorCalibrated struct {
-	FilamentMonitorCalibrated `mapstructure:",squash"`
+	FilamentMonitorCalibrated
 	// MmPerRev is extruded distance per revolution (in mm)
 	MmPerRev float64 `json:"mmPerRev"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (rmfmc *RotatingMagnetFilamentMonitorCalibrated) UnmarshalJSON(data []byte) error {
+	type rotatingMagnetFilamentMonitorCalibrated RotatingMagnetFilamentMonitorCalibrated
+	return extra.Unmarshal(data, (*rotatingMagnetFilamentMonitorCalibrated)(rmfmc), &rmfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (rmfmc RotatingMagnetFilamentMonitorCalibrated) MarshalJSON() ([]byte, error) {
+	type rotatingMagnetFilamentMonitorCalibrated RotatingMagnetFilamentMonitorCalibrated
+	return extra.Marshal(rotatingMagnetFilamentMonitorCalibrated(rmfmc), rmfmc.Extra)
 }
 
 // RotatingMagnetFilamentMonitorConfigured represents configured properties
 // of a pulsed filament sensor
 type RotatingMagnetFilamentMonitorConfigured struct {
-	FilamentMonitorConfigured `mapstructure:",squash"`
+	FilamentMonitorConfigured
 	// MmPerRev is extruded distance per revolution (in mm)
 	MmPerRev float64 `json:"mmPerRev"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (rmfmc *RotatingMagnetFilamentMonitorConfigured) UnmarshalJSON(data []byte) error {
+	type rotatingMagnetFilamentMonitorConfigured RotatingMagnetFilamentMonitorConfigured
+	return extra.Unmarshal(data, (*rotatingMagnetFilamentMonitorConfigured)(rmfmc), &rmfmc.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (rmfmc RotatingMagnetFilamentMonitorConfigured) MarshalJSON() ([]byte, error) {
+	type rotatingMagnetFilamentMonitorConfigured RotatingMagnetFilamentMonitorConfigured
+	return extra.Marshal(rotatingMagnetFilamentMonitorConfigured(rmfmc), rmfmc.Extra)
 }
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package sensors
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Sensors holds information about sensors
 type Sensors struct {
 	// Analog is a list of analog sensors
@@ -13,6 +15,20 @@ type Sensors struct {
 	GpIn []*GpInputPort `json:"gpIn"`
 	// Probes is a list of configured probes
 	Probes []Probe `json:"probes"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (s *Sensors) UnmarshalJSON(data []byte) error {
+	type sensors Sensors
+	return extra.Unmarshal(data, (*sensors)(s), &s.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (s Sensors) MarshalJSON() ([]byte, error) {
+	type sensors Sensors
+	return extra.Marshal(sensors(s), s.Extra)
 }
 
 // AnalogSensor represents an analog sensor
@@ -23,6 +39,20 @@ type AnalogSensor struct {
 	Name string `json:"name"`
 	// Type of this sensor
 	Type AnalogSensorType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (as *AnalogSensor) UnmarshalJSON(data []byte) error {
+	type analogSensor AnalogSensor
+	return extra.Unmarshal(data, (*analogSensor)(as), &as.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (as AnalogSensor) MarshalJSON() ([]byte, error) {
+	type analogSensor AnalogSensor
+	return extra.Marshal(analogSensor(as), as.Extra)
 }
 
 // AnalogSensorType represents supported analog sensor types
@@ -55,6 +85,20 @@ type Endstop struct {
 	Triggered bool `json:"triggered"`
 	// Type of this endstop
 	Type EndstopType `json:"type"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (e *Endstop) UnmarshalJSON(data []byte) error {
+	type endstop Endstop
+	return extra.Unmarshal(data, (*endstop)(e), &e.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (e Endstop) MarshalJSON() ([]byte, error) {
+	type endstop Endstop
+	return extra.Marshal(endstop(e), e.Extra)
 }
 
 // EndstopType represents the type of a configured enstop
@@ -77,6 +121,20 @@ const (
 type GpInputPort struct {
 	// Value of this port in range 0..1
 	Value float64 `json:"value"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (gip *GpInputPort) UnmarshalJSON(data []byte) error {
+	type gpInputPort GpInputPort
+	return extra.Unmarshal(data, (*gpInputPort)(gip), &gip.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (gip GpInputPort) MarshalJSON() ([]byte, error) {
+	type gpInputPort GpInputPort
+	return extra.Marshal(gpInputPort(gip), gip.Extra)
 }
 
 const (
@@ -113,12 +171,12 @@ type Probe struct {
 	RecoveryTime float64 `json:"recoveryTime"`
 	// Speed at which probing is performed (in mm/s)
 	// Deprecated: Use Speeds[0] instead
-	Speed float64 `json:"-"`
+	Speed float64 `json:"speed"`
 	// Speeds are fast and slow probing speeds (in mm/s)
 	Speeds []float64 `json:"speeds"`
 	// TemperatureCoefficient of the probe
 	// Deprecated: Use TemperatureCoefficients instead
-	TemperatureCoefficient float64 `json:"-"`
+	TemperatureCoefficient float64 `json:"temperatureCoefficient"`
 	// TemperatureCoefficients is a list of temperature coefficients
 	TemperatureCoefficients []float64 `json:"temperatureCoefficients"`
 	// Threshold at which the probe is considered to be triggered (0..1023)
@@ -133,6 +191,20 @@ type Probe struct {
 	Type ProbeType `json:"type"`
 	// Value are the current analog values of the probe
 	Value []int64 `json:"value"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (p *Probe) UnmarshalJSON(data []byte) error {
+	type probe Probe
+	return extra.Unmarshal(data, (*probe)(p), &p.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (p Probe) MarshalJSON() ([]byte, error) {
+	type probe Probe
+	return extra.Marshal(probe(p), p.Extra)
 }
 
 // ProbeType represents supported probe types
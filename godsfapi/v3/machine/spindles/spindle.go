@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package spindles
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 const (
 	// DefaultMaxRpm is the maximum RPM of a spindle
 	DefaultMaxRpm = 10000.0
@@ -22,6 +24,20 @@ type Spindle struct {
 	Max float64 `json:"max"`
 	// State is the current state
 	State SpindleState `json:"state"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (s *Spindle) UnmarshalJSON(data []byte) error {
+	type spindle Spindle
+	return extra.Unmarshal(data, (*spindle)(s), &s.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (s Spindle) MarshalJSON() ([]byte, error) {
+	type spindle Spindle
+	return extra.Marshal(spindle(s), s.Extra)
 }
 
 // SpindleState are the possible states of a spindle
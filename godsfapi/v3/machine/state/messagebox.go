@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package state
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // MessageBox holds information about the message box to show
 type MessageBox struct {
 	// AxisControls is a list of axis indices to show movement controls for
@@ -15,6 +17,20 @@ type MessageBox struct {
 	Timeout int64 `json:"timeout"`
 	// Title of the message box
 	Title string `json:"title"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (mb *MessageBox) UnmarshalJSON(data []byte) error {
+	type messageBox MessageBox
+	return extra.Unmarshal(data, (*messageBox)(mb), &mb.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (mb MessageBox) MarshalJSON() ([]byte, error) {
+	type messageBox MessageBox
+	return extra.Marshal(messageBox(mb), mb.Extra)
 }
 
 // MessageBoxMode represents supported modes of displaying a message box
@@ -1,7 +1,11 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package state
 
-import "time"
+import (
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+)
 
 const (
 	// NoTool is the tool index if no tool is selected
@@ -51,6 +55,20 @@ type State struct {
 	Time *time.Time `json:"time"`
 	// UpTime is how long the mchine has been running (in s)
 	UpTime uint64 `json:"upTime"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (s *State) UnmarshalJSON(data []byte) error {
+	type state State
+	return extra.Unmarshal(data, (*state)(s), &s.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (s State) MarshalJSON() ([]byte, error) {
+	type state State
+	return extra.Marshal(state(s), s.Extra)
 }
 
 // BeepRequest about a requested beep
@@ -59,12 +77,40 @@ type BeepRequest struct {
 	Duration uint64 `json:"duration"`
 	// Frequency of the requested beep (in Hz)
 	Frequency uint64 `json:"frequency"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (br *BeepRequest) UnmarshalJSON(data []byte) error {
+	type beepRequest BeepRequest
+	return extra.Unmarshal(data, (*beepRequest)(br), &br.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (br BeepRequest) MarshalJSON() ([]byte, error) {
+	type beepRequest BeepRequest
+	return extra.Marshal(beepRequest(br), br.Extra)
 }
 
 // GpOutputPort holds details about a general-purpose output port
 type GpOutputPort struct {
 	// Pwm value of this port in range 0..1
 	Pwm float64 `json:"pwm"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (gop *GpOutputPort) UnmarshalJSON(data []byte) error {
+	type gpOutputPort GpOutputPort
+	return extra.Unmarshal(data, (*gpOutputPort)(gop), &gop.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (gop GpOutputPort) MarshalJSON() ([]byte, error) {
+	type gpOutputPort GpOutputPort
+	return extra.Marshal(gpOutputPort(gop), gop.Extra)
 }
 
 // LogLevel represents the configured log leve
@@ -137,4 +183,18 @@ type RestorePoint struct {
 	SpindleSpeeds []float64 `json:"spindleSpeeds"`
 	// ToolNumber of the tool that was active
 	ToolNumber int64 `json:"toolNumber"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (rp *RestorePoint) UnmarshalJSON(data []byte) error {
+	type restorePoint RestorePoint
+	return extra.Unmarshal(data, (*restorePoint)(rp), &rp.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (rp RestorePoint) MarshalJSON() ([]byte, error) {
+	type restorePoint RestorePoint
+	return extra.Marshal(restorePoint(rp), rp.Extra)
 }
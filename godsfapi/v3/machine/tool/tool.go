@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package tool
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Default values for Tool
 const (
 	DefaultFilamentExtruder = -1
@@ -46,6 +48,20 @@ type Tool struct {
 	Standby []float64 `json:"standby"`
 	// State is the current state if this tool
 	State ToolState `json:"state"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (t *Tool) UnmarshalJSON(data []byte) error {
+	type tool Tool
+	return extra.Unmarshal(data, (*tool)(t), &t.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (t Tool) MarshalJSON() ([]byte, error) {
+	type tool Tool
+	return extra.Marshal(tool(t), t.Extra)
 }
 
 // ToolRetraction holds tool retraction parameters
@@ -60,6 +76,20 @@ type ToolRetraction struct {
 	UnretractSpeed float64 `json:"unretractSpeed"`
 	// ZHop is the amount of Z lift after doing a retraction (in mm)
 	ZHop float64 `json:"zHop"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (tr *ToolRetraction) UnmarshalJSON(data []byte) error {
+	type toolRetraction ToolRetraction
+	return extra.Unmarshal(data, (*toolRetraction)(tr), &tr.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (tr ToolRetraction) MarshalJSON() ([]byte, error) {
+	type toolRetraction ToolRetraction
+	return extra.Marshal(toolRetraction(tr), tr.Extra)
 }
 
 // ToolState are the states of tool
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package usersessions
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // UserSession represents a user session
 type UserSession struct {
 	// Id is the identifier of this session
@@ -14,6 +16,20 @@ type UserSession struct {
 	// OriginId is the corresponding identifier. If it is a remote session it is the remote port
 	// else it defaults to the PID of the current process
 	OriginId int `json:"originId"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (us *UserSession) UnmarshalJSON(data []byte) error {
+	type userSession UserSession
+	return extra.Unmarshal(data, (*userSession)(us), &us.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (us UserSession) MarshalJSON() ([]byte, error) {
+	type userSession UserSession
+	return extra.Marshal(userSession(us), us.Extra)
 }
 
 // AccessLevel defines what a user is allowed to do
@@ -1,10 +1,26 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package uservariables
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // UserVariable is a key-value pair for user-defined variables
 type UserVariable struct {
 	// Name (key) of the variable
 	Name string `json:"name"`
 	// Value of the variable
 	Value string `json:"value"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (uv *UserVariable) UnmarshalJSON(data []byte) error {
+	type userVariable UserVariable
+	return extra.Unmarshal(data, (*userVariable)(uv), &uv.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (uv UserVariable) MarshalJSON() ([]byte, error) {
+	type userVariable UserVariable
+	return extra.Marshal(userVariable(uv), uv.Extra)
 }
@@ -1,6 +1,8 @@
 // Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
 package volume
 
+import "github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+
 // Volume holds information about a storage device
 type Volume struct {
 	// Capacity is the total capacity of the storage device in bytes (0 for unknown)
@@ -17,4 +19,18 @@ type Volume struct {
 	Path string `json:"path"`
 	// Speed of the storage device in bytes/s (0 for unknown)
 	Speed uint64 `json:"speed"`
+	// Extra holds JSON members that are unknown to this type
+	Extra extra.Fields `json:"-"`
+}
+
+// UnmarshalJSON decodes the known fields and retains unknown members in Extra
+func (v *Volume) UnmarshalJSON(data []byte) error {
+	type volume Volume
+	return extra.Unmarshal(data, (*volume)(v), &v.Extra)
+}
+
+// MarshalJSON encodes the known fields followed by the members in Extra
+func (v Volume) MarshalJSON() ([]byte, error) {
+	type volume Volume
+	return extra.Marshal(volume(v), v.Extra)
 }
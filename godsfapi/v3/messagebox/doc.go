@@ -0,0 +1,11 @@
+/*
+Package messagebox shows message boxes to the operator and waits for answers.
+
+A Client sends M291 to show a message box and follows state.messageBox of the
+object model to see when it is closed. Which button was pressed is taken from
+the M292 codes the Client observes, e.g. through an InterceptConnection in
+executed mode. Respond answers a message box by its sequence number so that
+message boxes of other programs or the firmware can be acknowledged, too.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package messagebox
@@ -0,0 +1,474 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package messagebox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+var (
+	// ErrNoMessageBox is returned if there is no message box to respond to
+	ErrNoMessageBox = errors.New("No message box is shown")
+	// ErrSeqMismatch is returned if another message box than the requested one is shown
+	ErrSeqMismatch = errors.New("Message box sequence number does not match")
+)
+
+// DefaultResponseDelay is how long a Client waits for the M292 of a message box
+// that has disappeared from the object model
+const DefaultResponseDelay = time.Second
+
+// Result tells how a message box was closed
+type Result int
+
+const (
+	// Ok if the Ok button was pressed
+	Ok Result = iota
+	// Cancelled if the Cancel button was pressed
+	Cancelled
+	// Closed if the message box was closed without a choice, e.g. by its Close button
+	Closed
+	// TimedOut if the message box was closed because its timeout elapsed
+	TimedOut
+)
+
+// String returns the name of this result
+func (r Result) String() string {
+	switch r {
+	case Ok:
+		return "ok"
+	case Cancelled:
+		return "cancelled"
+	case Closed:
+		return "closed"
+	case TimedOut:
+		return "timed out"
+	default:
+		return fmt.Sprintf("Result(%d)", int(r))
+	}
+}
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// CodeReceiver delivers observed codes, e.g. a connection.InterceptConnection
+type CodeReceiver interface {
+	ReceiveCode() (*commands.Code, error)
+	IgnoreCode() error
+}
+
+// response is an observed M292
+type response struct {
+	cancel bool
+	// seq is the sequence number the response refers to or -1 if it had none
+	seq int64
+}
+
+// Client shows message boxes and tracks their responses
+type Client struct {
+	performer CodePerformer
+	// Channel to send M291 to
+	Channel types.CodeChannel
+	// ResponseChannel to send M292 to. It must differ from Channel because
+	// M291 blocks its channel until the message box is closed.
+	ResponseChannel types.CodeChannel
+	// ResponseDelay is how long to wait for the M292 after the message box has
+	// disappeared, because the object model may be updated before the code is seen
+	ResponseDelay time.Duration
+
+	mu      sync.Mutex
+	box     *state.MessageBox
+	axes    []axis
+	last    *response
+	changed chan struct{}
+}
+
+// axis is the part of an axis that is needed to show axis controls
+type axis struct {
+	Letter string `json:"letter"`
+}
+
+// NewClient creates a new Client sending codes to the given performer
+func NewClient(p CodePerformer) *Client {
+	return &Client{
+		performer:       p,
+		Channel:         types.DefaultChannel,
+		ResponseChannel: types.HTTP,
+		ResponseDelay:   DefaultResponseDelay,
+		changed:         make(chan struct{}),
+	}
+}
+
+// notify wakes up everybody waiting for a change. Must be called with mu held.
+func (c *Client) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// SetMessageBox sets the current message box, e.g. from a full object model
+func (c *Client) SetMessageBox(mb *state.MessageBox) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if mb != nil {
+		cp := *mb
+		mb = &cp
+	}
+	c.box = mb
+	c.notify()
+}
+
+// SetAxes sets the axis letters used to map axis controls to M291 parameters
+func (c *Client) SetAxes(letters []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.axes = make([]axis, len(letters))
+	for i, l := range letters {
+		c.axes[i].Letter = l
+	}
+}
+
+// ApplyPatch applies a patch of a SubscribeConnection
+func (c *Client) ApplyPatch(patch []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	type moveAxes struct {
+		Axes *[]axis `json:"axes"`
+	}
+	type stateBox struct {
+		MessageBox json.RawMessage `json:"messageBox"`
+	}
+	p := struct {
+		Move  moveAxes `json:"move"`
+		State stateBox `json:"state"`
+	}{Move: moveAxes{Axes: &c.axes}}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+
+	raw := p.State.MessageBox
+	if raw == nil {
+		return nil
+	}
+	if string(raw) == "null" {
+		c.box = nil
+	} else {
+		if c.box == nil {
+			c.box = &state.MessageBox{}
+		}
+		if err := json.Unmarshal(raw, c.box); err != nil {
+			return err
+		}
+	}
+	c.notify()
+	return nil
+}
+
+// Watch keeps the message box up to date until the subscription fails
+func (c *Client) Watch(sub connection.ModelSubscription) error {
+	mm, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	letters := make([]string, len(mm.Move.Axes))
+	for i, a := range mm.Move.Axes {
+		letters[i] = a.Letter
+	}
+	c.SetAxes(letters)
+	c.SetMessageBox(mm.State.MessageBox)
+
+	for {
+		patch, err := sub.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		if err = c.ApplyPatch([]byte(patch)); err != nil {
+			return err
+		}
+	}
+}
+
+// HandleCode records the response if the given code is M292
+func (c *Client) HandleCode(code *commands.Code) {
+	if code.Type != commands.MCode || !code.IsMajorNumber(292) {
+		return
+	}
+	r := response{seq: -1}
+	if p := code.Parameter("P"); p != nil {
+		if v, err := p.AsInt64(); err == nil {
+			r.cancel = v != 0
+		}
+	}
+	if s := code.Parameter("S"); s != nil {
+		if v, err := s.AsInt64(); err == nil {
+			r.seq = v
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last = &r
+	c.notify()
+}
+
+// Intercept passes every code of the given receiver to HandleCode until it fails.
+// The receiver should be connected in pre mode and may be filtered to M292, so
+// that responses are usually seen before the message box disappears.
+func (c *Client) Intercept(r CodeReceiver) error {
+	for {
+		code, err := r.ReceiveCode()
+		if err != nil {
+			return err
+		}
+		c.HandleCode(code)
+		if err = r.IgnoreCode(); err != nil {
+			return err
+		}
+	}
+}
+
+// Current returns a copy of the message box that is shown or nil if there is none
+func (c *Client) Current() *state.MessageBox {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.box == nil || c.box.Mode == nil {
+		return nil
+	}
+	cp := *c.box
+	return &cp
+}
+
+// shown returns the sequence number of the shown message box and whether there
+// is one. Must be called with mu held.
+func (c *Client) shown() (int64, bool) {
+	if c.box == nil || c.box.Mode == nil {
+		return 0, false
+	}
+	return c.box.Seq, true
+}
+
+// Respond answers the message box with the given sequence number.
+// If cancel is true the Cancel button is pressed, else the Ok or Close button.
+func (c *Client) Respond(seq int64, cancel bool) error {
+	c.mu.Lock()
+	current, ok := c.shown()
+	c.mu.Unlock()
+	if !ok {
+		return ErrNoMessageBox
+	}
+	if current != seq {
+		return fmt.Errorf("%w: %d is shown instead of %d", ErrSeqMismatch, current, seq)
+	}
+	return c.respond(seq, cancel)
+}
+
+func (c *Client) respond(seq int64, cancel bool) error {
+	p := 0
+	if cancel {
+		p = 1
+	}
+	return c.perform(fmt.Sprintf("M292 P%d S%d", p, seq), c.ResponseChannel)
+}
+
+// perform runs a code and turns error replies into errors
+func (c *Client) perform(code string, channel types.CodeChannel) error {
+	reply, err := c.performer.PerformSimpleCode(code, channel)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(reply, "Error") {
+		return errors.New(strings.TrimSpace(reply))
+	}
+	return nil
+}
+
+// ShowMessageBox shows a message box and waits until it is closed.
+// axisControls is a bit mask of the axis indices to show jog controls for.
+// If ctx has a deadline it becomes the timeout of the message box. If ctx is done
+// before the message box is closed, it is dismissed and TimedOut is returned
+// for an exceeded deadline or the error of ctx otherwise.
+func (c *Client) ShowMessageBox(ctx context.Context, title, message string, mode state.MessageBoxMode, axisControls uint64) (Result, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "M291 P%s R%s S%d", quote(message), quote(title), mode)
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		timeout := math.Ceil(time.Until(deadline).Seconds())
+		if timeout < 1 {
+			return TimedOut, nil
+		}
+		fmt.Fprintf(&b, " T%g", timeout)
+	} else {
+		b.WriteString(" T0")
+	}
+
+	c.mu.Lock()
+	for i := 0; i < 64 && axisControls>>uint(i) != 0; i++ {
+		if axisControls&(1<<uint(i)) != 0 {
+			if i >= len(c.axes) {
+				c.mu.Unlock()
+				return Closed, fmt.Errorf("Axis %d does not exist", i)
+			}
+			fmt.Fprintf(&b, " %s1", c.axes[i].Letter)
+		}
+	}
+	before, hadBox := c.shown()
+	c.last = nil
+	c.mu.Unlock()
+
+	// M291 S2 and S3 block until the message box is closed so run it in the background
+	done := make(chan error, 1)
+	go func() {
+		done <- c.perform(b.String(), c.Channel)
+	}()
+
+	// Wait for the message box to show up
+	var seq int64
+	performed := false
+	for {
+		c.mu.Lock()
+		current, ok := c.shown()
+		changed := c.changed
+		if ok && (!hadBox || current != before) {
+			seq = current
+			c.mu.Unlock()
+			break
+		}
+		c.mu.Unlock()
+		if performed && (mode == state.OkOnly || mode == state.OkCancel) {
+			// Blocking message boxes are closed when M291 returns, so this one
+			// was closed before it was seen and its sequence number is unknown
+			seq = -1
+			break
+		}
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return Closed, err
+			}
+			done, performed = nil, true
+		case <-changed:
+		case <-ctx.Done():
+			return c.abandon(ctx, done, performed, before, hadBox, mode)
+		}
+	}
+
+	// Wait for it to be closed. The response may be seen after the message box
+	// has disappeared, so it is waited for a little longer then.
+	closed := func() (Result, error) {
+		if hasDeadline && !time.Now().Before(deadline) {
+			return TimedOut, nil
+		}
+		return Closed, nil
+	}
+	var grace <-chan time.Time
+	for {
+		c.mu.Lock()
+		current, ok := c.shown()
+		changed := c.changed
+		last := c.last
+		c.mu.Unlock()
+		open := ok && current == seq
+		if last != nil && (seq < 0 || last.seq == seq || (last.seq < 0 && (open || grace != nil))) {
+			// Forget the answer so that it is not taken for the next message box
+			c.mu.Lock()
+			c.last = nil
+			c.mu.Unlock()
+			if last.cancel {
+				return Cancelled, nil
+			}
+			if mode == state.OkOnly || mode == state.OkCancel {
+				return Ok, nil
+			}
+			return Closed, nil
+		}
+		if !open && grace == nil {
+			if c.ResponseDelay <= 0 {
+				return closed()
+			}
+			t := time.NewTimer(c.ResponseDelay)
+			defer t.Stop()
+			grace = t.C
+		}
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return Closed, err
+			}
+			done = nil
+		case <-changed:
+		case <-grace:
+			return closed()
+		case <-ctx.Done():
+			if !open {
+				return closed()
+			}
+			if err := c.respond(seq, true); err != nil {
+				return Closed, err
+			}
+			return c.cancelled(ctx)
+		}
+	}
+}
+
+// abandon dismisses a requested message box that has not shown up yet. The box
+// is waited for so that it does not stay open without anybody waiting for it.
+func (c *Client) abandon(ctx context.Context, done <-chan error, performed bool, before int64, hadBox bool, mode state.MessageBoxMode) (Result, error) {
+	for {
+		c.mu.Lock()
+		current, ok := c.shown()
+		changed := c.changed
+		c.mu.Unlock()
+		if ok && (!hadBox || current != before) {
+			if err := c.respond(current, true); err != nil {
+				return Closed, err
+			}
+			return c.cancelled(ctx)
+		}
+		if performed {
+			if mode == state.OkOnly || mode == state.OkCancel {
+				// Blocking message boxes are closed when M291 returns
+				return c.cancelled(ctx)
+			}
+			// Other message boxes are shown when M291 returns and only one can be shown
+			if err := c.perform("M292 P1", c.ResponseChannel); err != nil {
+				return Closed, err
+			}
+			return c.cancelled(ctx)
+		}
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return Closed, err
+			}
+			performed = true
+		case <-changed:
+		}
+	}
+}
+
+// cancelled returns the result of a message box that was given up on
+func (c *Client) cancelled(ctx context.Context) (Result, error) {
+	if ctx.Err() == context.DeadlineExceeded {
+		return TimedOut, nil
+	}
+	return Closed, ctx.Err()
+}
+
+// quote encloses s in double quotes like a string parameter of a G-code
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
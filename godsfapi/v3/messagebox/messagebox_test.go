@@ -0,0 +1,107 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package messagebox
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// fakePerformer reports every M291 it receives and blocks it like the firmware
+// until the message box is closed
+type fakePerformer struct {
+	shown  chan string
+	closed chan struct{}
+}
+
+func (f *fakePerformer) PerformSimpleCode(code string, channel types.CodeChannel) (string, error) {
+	if strings.HasPrefix(code, "M291") {
+		f.shown <- code
+		<-f.closed
+	}
+	return "", nil
+}
+
+// m292 creates the code sent when a message box button is pressed
+func m292(cancel bool, seq int64) *commands.Code {
+	c := commands.NewCode()
+	c.Type = commands.MCode
+	n := int64(292)
+	c.MajorNumber = &n
+	p := int64(0)
+	if cancel {
+		p = 1
+	}
+	c.Parameters = []commands.CodeParameter{*commands.NewSimpleCodeParameter("P", p), *commands.NewSimpleCodeParameter("S", seq)}
+	return c
+}
+
+func TestShowMessageBox(t *testing.T) {
+	const shown = `{"state":{"messageBox":{"mode":2,"seq":5,"title":"Title","message":"Press OK"}}}`
+	const hidden = `{"state":{"messageBox":null}}`
+
+	tests := []struct {
+		name     string
+		code     *commands.Code
+		before   bool
+		expected Result
+	}{
+		{"code before patch", m292(false, 5), true, Ok},
+		{"patch before code", m292(false, 5), false, Ok},
+		{"cancel after patch", m292(true, 5), false, Cancelled},
+		{"no code", nil, false, Closed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &fakePerformer{shown: make(chan string, 1), closed: make(chan struct{})}
+			c := NewClient(p)
+			c.ResponseDelay = 100 * time.Millisecond
+
+			type outcome struct {
+				r   Result
+				err error
+			}
+			result := make(chan outcome, 1)
+			go func() {
+				r, err := c.ShowMessageBox(context.Background(), "Title", "Press OK", state.OkOnly, 0)
+				result <- outcome{r, err}
+			}()
+
+			select {
+			case <-p.shown:
+			case <-time.After(5 * time.Second):
+				t.Fatal("M291 was not sent")
+			}
+			if err := c.ApplyPatch([]byte(shown)); err != nil {
+				t.Fatalf("ApplyPatch failed: %v", err)
+			}
+			// Give the client time to see the message box
+			time.Sleep(20 * time.Millisecond)
+			if tt.code != nil && tt.before {
+				c.HandleCode(tt.code)
+			}
+			if err := c.ApplyPatch([]byte(hidden)); err != nil {
+				t.Fatalf("ApplyPatch failed: %v", err)
+			}
+			close(p.closed)
+			if tt.code != nil && !tt.before {
+				time.Sleep(10 * time.Millisecond)
+				c.HandleCode(tt.code)
+			}
+
+			select {
+			case o := <-result:
+				if o.err != nil || o.r != tt.expected {
+					t.Errorf("ShowMessageBox returned %s, %v, expected %s", o.r, o.err, tt.expected)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("ShowMessageBox did not return")
+			}
+		})
+	}
+}
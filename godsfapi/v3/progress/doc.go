@@ -0,0 +1,14 @@
+/*
+Package progress combines the partial progress signals of a job into a single
+progress fraction and time estimate.
+
+The object model reports several independent signals about a running job: the
+position in the file, the amount of filament used, the current layer and the
+remaining times estimated by RepRapFirmware and the slicer. Each of them is
+turned into a SignalEstimate and weighted by how reliable it usually is at the
+current stage of the job. Pause and warm-up times are excluded from all
+calculations. An Estimator keeps the job state up to date from object model
+patches so the estimate can be refreshed on every update.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package progress
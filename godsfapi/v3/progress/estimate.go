@@ -0,0 +1,272 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package progress
+
+import (
+	"math"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/job"
+)
+
+// Signal identifies a source of progress information
+type Signal string
+
+const (
+	// FileSignal is based on the position in the file
+	FileSignal Signal = "file"
+	// FilamentSignal is based on the filament usage
+	FilamentSignal = "filament"
+	// LayerSignal is based on the number of printed layers
+	LayerSignal = "layer"
+	// SlicerSignal is based on the print time estimated by the slicer
+	SlicerSignal = "slicer"
+)
+
+// Base weights of the signals. The file position is a rough measure because
+// the amount of work per byte varies a lot, layers are more meaningful and
+// filament usage as well as slicer estimates are usually the most accurate.
+const (
+	fileWeight     = 1.0
+	layerWeight    = 1.5
+	filamentWeight = 2.0
+	slicerWeight   = 2.0
+)
+
+// MinUncertainty is the relative uncertainty of the remaining time at the
+// beginning of a job if all signals agree. It decreases linearly towards the end.
+const MinUncertainty = 0.1
+
+// SignalEstimate is the estimate derived from a single signal
+type SignalEstimate struct {
+	// Signal this estimate is based on
+	Signal Signal `json:"signal"`
+	// Fraction of the job that has been completed (0..1)
+	Fraction float64 `json:"fraction"`
+	// Remaining time or nil if it cannot be determined yet
+	Remaining *time.Duration `json:"remaining"`
+	// Weight of this signal in the combined estimate
+	Weight float64 `json:"weight"`
+}
+
+// Estimate is the combined progress estimate of a job
+type Estimate struct {
+	// FileName of the job
+	FileName string `json:"fileName"`
+	// Fraction of the job that has been completed (0..1)
+	Fraction float64 `json:"fraction"`
+	// Elapsed is the time spent printing excluding pause and warm-up times
+	Elapsed time.Duration `json:"elapsed"`
+	// Remaining is the estimated remaining time or nil if unknown
+	Remaining *time.Duration `json:"remaining"`
+	// RemainingMin is the lower bound of the remaining time or nil if unknown
+	RemainingMin *time.Duration `json:"remainingMin"`
+	// RemainingMax is the upper bound of the remaining time or nil if unknown
+	RemainingMax *time.Duration `json:"remainingMax"`
+	// Dominant is the signal with the highest weight
+	Dominant Signal `json:"dominant"`
+	// Signals holds the individual estimates that were combined
+	Signals []SignalEstimate `json:"signals"`
+}
+
+// ETA returns the estimated time of completion relative to now or nil if unknown
+func (e *Estimate) ETA(now time.Time) *time.Time {
+	if e.Remaining == nil {
+		return nil
+	}
+	t := now.Add(*e.Remaining)
+	return &t
+}
+
+// EstimateJob calculates the progress of the given job. If info is nil the file
+// information of the job is used. Nil is returned if no job is being processed.
+// The file position and duration tell if a job is running because the file name
+// is not reset when the control server sets it to null.
+func EstimateJob(j *job.Job, info *job.ParsedFileInfo) *Estimate {
+	if j.FilePosition == nil || j.Duration == nil {
+		return nil
+	}
+	if info == nil {
+		info = &j.File
+	}
+	if info.FileName == "" {
+		return nil
+	}
+
+	elapsed := ElapsedTime(j)
+	signals := make([]SignalEstimate, 0, 4)
+	if s := fileEstimate(j, info, elapsed); s != nil {
+		signals = append(signals, *s)
+	}
+	if s := filamentEstimate(j, info, elapsed); s != nil {
+		signals = append(signals, *s)
+	}
+	if s := layerEstimate(j, info, elapsed); s != nil {
+		signals = append(signals, *s)
+	}
+	if s := slicerEstimate(j, info, elapsed); s != nil {
+		signals = append(signals, *s)
+	}
+	return combine(info.FileName, elapsed, signals)
+}
+
+// ElapsedTime returns the duration of the job excluding pause and warm-up times
+func ElapsedTime(j *job.Job) time.Duration {
+	if j.Duration == nil {
+		return 0
+	}
+	d := int64(*j.Duration)
+	if j.PauseDuration != nil {
+		d -= *j.PauseDuration
+	}
+	if j.WarmUpDuration != nil {
+		d -= *j.WarmUpDuration
+	}
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d) * time.Second
+}
+
+// newSignalEstimate creates a SignalEstimate. If remaining is nil it is extrapolated
+// from the fraction and the elapsed time.
+func newSignalEstimate(signal Signal, fraction float64, remaining *int64, elapsed time.Duration, weight float64) *SignalEstimate {
+	fraction = clamp(fraction)
+	s := &SignalEstimate{Signal: signal, Fraction: fraction, Weight: weight}
+	if remaining != nil {
+		r := time.Duration(*remaining) * time.Second
+		s.Remaining = &r
+	} else if fraction > 0 && elapsed > 0 {
+		r := time.Duration(float64(elapsed) * (1 - fraction) / fraction).Round(time.Second)
+		s.Remaining = &r
+	}
+	return s
+}
+
+func fileEstimate(j *job.Job, info *job.ParsedFileInfo, elapsed time.Duration) *SignalEstimate {
+	if j.FilePosition == nil || info.Size == 0 {
+		return nil
+	}
+	f := float64(*j.FilePosition) / float64(info.Size)
+	return newSignalEstimate(FileSignal, f, j.TimesLeft.File, elapsed, fileWeight*(0.5+f))
+}
+
+// filamentEstimate compares the filament of the finished layers with the total.
+// The object model does not report the filament used in the current layer so it is
+// extrapolated from the last layer like in layerEstimate.
+func filamentEstimate(j *job.Job, info *job.ParsedFileInfo, elapsed time.Duration) *SignalEstimate {
+	total := sum(info.Filament)
+	n := len(j.Layers)
+	if total <= 0 || n == 0 {
+		return nil
+	}
+	var used float64
+	for _, l := range j.Layers {
+		used += sum(l.Filament)
+	}
+	if last := j.Layers[n-1]; j.LayerTime != nil && last.Duration > 0 {
+		used += sum(last.Filament) * math.Min(*j.LayerTime/last.Duration, 0.99)
+	}
+	f := used / total
+	return newSignalEstimate(FilamentSignal, f, j.TimesLeft.Filament, elapsed, filamentWeight*(0.25+f))
+}
+
+func layerEstimate(j *job.Job, info *job.ParsedFileInfo, elapsed time.Duration) *SignalEstimate {
+	if j.Layer == nil || info.NumLayers <= 0 {
+		return nil
+	}
+	done := float64(*j.Layer - 1)
+	if n := len(j.Layers); n > 0 && j.LayerTime != nil {
+		// Interpolate within the current layer using the duration of the last one
+		if last := j.Layers[n-1].Duration; last > 0 {
+			done += math.Min(*j.LayerTime/last, 0.99)
+		}
+	}
+	f := done / float64(info.NumLayers)
+	return newSignalEstimate(LayerSignal, f, nil, elapsed, layerWeight*(0.25+f))
+}
+
+func slicerEstimate(j *job.Job, info *job.ParsedFileInfo, elapsed time.Duration) *SignalEstimate {
+	remaining := j.TimesLeft.Slicer
+	if remaining == nil {
+		total := info.SimulatedTime
+		if total == nil {
+			total = info.PrintTime
+		}
+		if total == nil || *total == 0 {
+			return nil
+		}
+		r := int64(*total) - int64(elapsed/time.Second)
+		if r < 0 {
+			r = 0
+		}
+		remaining = &r
+	}
+	var f float64
+	if total := elapsed.Seconds() + float64(*remaining); total > 0 {
+		f = elapsed.Seconds() / total
+	}
+	return newSignalEstimate(SlicerSignal, f, remaining, elapsed, slicerWeight)
+}
+
+// combine calculates the weighted average of the given signals
+func combine(fileName string, elapsed time.Duration, signals []SignalEstimate) *Estimate {
+	e := &Estimate{FileName: fileName, Elapsed: elapsed, Signals: signals}
+	var fractionWeight, fraction, remainingWeight, remaining float64
+	var dominantWeight float64
+	for _, s := range signals {
+		fraction += s.Fraction * s.Weight
+		fractionWeight += s.Weight
+		if s.Remaining != nil {
+			remaining += s.Remaining.Seconds() * s.Weight
+			remainingWeight += s.Weight
+		}
+		if s.Weight > dominantWeight {
+			e.Dominant, dominantWeight = s.Signal, s.Weight
+		}
+	}
+	if fractionWeight > 0 {
+		e.Fraction = fraction / fractionWeight
+	}
+	if remainingWeight == 0 {
+		return e
+	}
+	remaining /= remainingWeight
+
+	// Spread is the weighted standard deviation of the individual estimates
+	var variance float64
+	for _, s := range signals {
+		if s.Remaining != nil {
+			d := s.Remaining.Seconds() - remaining
+			variance += d * d * s.Weight
+		}
+	}
+	spread := math.Max(math.Sqrt(variance/remainingWeight), remaining*MinUncertainty*(1-e.Fraction))
+
+	r := seconds(remaining)
+	min := seconds(math.Max(remaining-spread, 0))
+	max := seconds(remaining + spread)
+	e.Remaining, e.RemainingMin, e.RemainingMax = &r, &min, &max
+	return e
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s)) * time.Second
+}
+
+func sum(values []float64) float64 {
+	var s float64
+	for _, v := range values {
+		s += v
+	}
+	return s
+}
+
+func clamp(f float64) float64 {
+	if math.IsNaN(f) || f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
@@ -0,0 +1,98 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package progress
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/job"
+)
+
+// Estimator keeps track of the job state and calculates estimates on demand
+type Estimator struct {
+	mu       sync.Mutex
+	job      job.Job
+	fileInfo *job.ParsedFileInfo
+}
+
+// NewEstimator creates a new Estimator without job information
+func NewEstimator() *Estimator {
+	return &Estimator{}
+}
+
+// SetJob replaces the tracked job state
+func (e *Estimator) SetJob(j job.Job) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	before := e.job.File.FileName
+	e.job = j
+	e.fileChanged(before)
+}
+
+// fileChanged drops the file information override if the job file is no longer
+// the one it was set for. Must be called with mu held.
+func (e *Estimator) fileChanged(before string) {
+	name := e.job.File.FileName
+	if e.fileInfo != nil && name != before && e.fileInfo.FileName != name {
+		e.fileInfo = nil
+	}
+}
+
+// SetFileInfo overrides the file information of the job, e.g. with the result
+// of CommandConnection.GetFileInfo. Pass nil to use the job file again.
+// The override is dropped when another file is started.
+func (e *Estimator) SetFileInfo(info *job.ParsedFileInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if info != nil {
+		fi := *info
+		info = &fi
+	}
+	e.fileInfo = info
+}
+
+// ApplyPatch merges an object model patch into the tracked job state.
+// Parts of the patch that do not belong to the job are ignored.
+func (e *Estimator) ApplyPatch(patch []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	before := e.job.File.FileName
+	p := struct {
+		Job *job.Job `json:"job"`
+	}{Job: &e.job}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+	e.fileChanged(before)
+	return nil
+}
+
+// Estimate calculates the current estimate or returns nil if no job is running
+func (e *Estimator) Estimate() *Estimate {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return EstimateJob(&e.job, e.fileInfo)
+}
+
+// Watch receives updates from the given subscription and calls f with a new
+// estimate after each update until an error occurs
+func (e *Estimator) Watch(sub connection.ModelSubscription, f func(*Estimate)) error {
+	m, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	e.SetJob(m.Job)
+	f(e.Estimate())
+
+	for {
+		patch, err := sub.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		if err = e.ApplyPatch([]byte(patch)); err != nil {
+			return err
+		}
+		f(e.Estimate())
+	}
+}
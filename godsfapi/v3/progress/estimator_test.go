@@ -0,0 +1,51 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package progress
+
+import (
+	"testing"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/job"
+)
+
+func TestEstimatorJobEnd(t *testing.T) {
+	e := NewEstimator()
+	start := `{"job":{"duration":60,"filePosition":1000,"file":{"fileName":"0:/gcodes/a.gcode","size":4000}}}`
+	if err := e.ApplyPatch([]byte(start)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	est := e.Estimate()
+	if est == nil {
+		t.Fatal("No estimate while the job is running")
+	}
+	if len(est.Signals) != 1 || est.Signals[0].Fraction != 0.25 {
+		t.Errorf("Unexpected signals %+v", est.Signals)
+	}
+
+	end := `{"job":{"duration":null,"filePosition":null,"file":{"fileName":null,"size":0}}}`
+	if err := e.ApplyPatch([]byte(end)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if est = e.Estimate(); est != nil {
+		t.Errorf("Expected no estimate after the job but got %+v", est)
+	}
+}
+
+func TestEstimatorFileInfo(t *testing.T) {
+	e := NewEstimator()
+	e.SetFileInfo(&job.ParsedFileInfo{FileName: "0:/gcodes/a.gcode", Size: 2000})
+	start := `{"job":{"duration":60,"filePosition":1000,"file":{"fileName":"0:/gcodes/a.gcode","size":4000}}}`
+	if err := e.ApplyPatch([]byte(start)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if est := e.Estimate(); est == nil || est.Signals[0].Fraction != 0.5 {
+		t.Errorf("File information override was not used: %+v", est)
+	}
+
+	next := `{"job":{"duration":10,"filePosition":1000,"file":{"fileName":"0:/gcodes/b.gcode","size":4000}}}`
+	if err := e.ApplyPatch([]byte(next)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if est := e.Estimate(); est == nil || est.Signals[0].Fraction != 0.25 {
+		t.Errorf("File information override was kept for the next job: %+v", est)
+	}
+}
@@ -0,0 +1,13 @@
+/*
+Package query resolves DSF-style paths against a machine.MachineModel or decoded
+JSON data.
+
+Paths use the same syntax as subscription filters. Segments are separated by
+slashes or dots and may be followed by list indices, for example
+move/axes[2]/userPosition or move.axes[2].userPosition. The wildcard * matches
+any key, [*] matches any list item and a trailing ** matches the whole subtree.
+Queries on typed models return the Go values of the corresponding fields.
+Unknown members retained by the object model types are resolved as well.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package query
@@ -0,0 +1,210 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SegmentKind is the kind of a path segment
+type SegmentKind int
+
+// Valid SegmentKind values
+const (
+	// Key selects a named field or map entry
+	Key SegmentKind = iota
+	// Index selects a list item
+	Index
+	// AnyKey (*) selects every field, map entry or list item
+	AnyKey
+	// AnyIndex ([*]) selects every list item
+	AnyIndex
+	// Recursive (**) selects the whole subtree and may only appear at the end
+	Recursive
+)
+
+// Segment is a single element of a Path
+type Segment struct {
+	// Kind of this segment
+	Kind SegmentKind
+	// Key to select if Kind is Key
+	Key string
+	// Index to select if Kind is Index
+	Index int
+}
+
+// String returns the textual representation of this segment
+func (s Segment) String() string {
+	switch s.Kind {
+	case Index:
+		return "[" + strconv.Itoa(s.Index) + "]"
+	case AnyKey:
+		return "*"
+	case AnyIndex:
+		return "[*]"
+	case Recursive:
+		return "**"
+	default:
+		return s.Key
+	}
+}
+
+// IsWildcard returns true if this segment may select more than one value
+func (s Segment) IsWildcard() bool {
+	return s.Kind == AnyKey || s.Kind == AnyIndex || s.Kind == Recursive
+}
+
+// Path is a parsed DSF-style path
+type Path []Segment
+
+// String returns the path in slash-separated form like move/axes[2]/userPosition
+func (p Path) String() string {
+	var b strings.Builder
+	for i, s := range p {
+		if i > 0 && s.Kind != Index && s.Kind != AnyIndex {
+			b.WriteByte('/')
+		}
+		b.WriteString(s.String())
+	}
+	return b.String()
+}
+
+// HasWildcard returns true if this path may select more than one value
+func (p Path) HasWildcard() bool {
+	for _, s := range p {
+		if s.IsWildcard() {
+			return true
+		}
+	}
+	return false
+}
+
+// Append returns a copy of this path with the given segment appended.
+// The receiver is never modified so that paths can be extended from a shared prefix.
+func (p Path) Append(s Segment) Path {
+	result := make(Path, len(p), len(p)+1)
+	copy(result, p)
+	return append(result, s)
+}
+
+// SyntaxError is returned if a path cannot be parsed
+type SyntaxError struct {
+	// Path that was parsed
+	Path string
+	// Offset of the error in bytes
+	Offset int
+	// Msg describes the problem
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Invalid path %q at offset %d: %s", e.Path, e.Offset, e.Msg)
+}
+
+// Parse parses a slash- or dot-separated path. An empty path selects the root
+// but empty segments such as in move//axes or a trailing separator are invalid.
+func Parse(path string) (Path, error) {
+	if path == "" {
+		return nil, nil
+	}
+	var p Path
+	start := 0
+	for start <= len(path) {
+		end := start
+		for end < len(path) && path[end] != '/' && path[end] != '.' {
+			if path[end] == '[' {
+				// Skip brackets so that dots inside them are not treated as separators
+				for end < len(path) && path[end] != ']' {
+					end++
+				}
+				if end == len(path) {
+					return nil, &SyntaxError{Path: path, Offset: start, Msg: "unterminated index"}
+				}
+			}
+			end++
+		}
+		if end == start {
+			return nil, &SyntaxError{Path: path, Offset: start, Msg: "empty segment"}
+		}
+		segments, err := parseSegment(path, start, path[start:end])
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, segments...)
+		start = end + 1
+	}
+
+	for i, s := range p {
+		if s.Kind == Recursive && i != len(p)-1 {
+			return nil, &SyntaxError{Path: path, Offset: strings.Index(path, "**"), Msg: "** may only be used at the end"}
+		}
+	}
+	return p, nil
+}
+
+// MustParse is like Parse but panics if the path is invalid
+func MustParse(path string) Path {
+	p, err := Parse(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// parseSegment parses a part between separators like axes[2] or [*]
+func parseSegment(path string, offset int, part string) ([]Segment, error) {
+	var result []Segment
+	name := part
+	if i := strings.IndexByte(part, '['); i >= 0 {
+		name = part[:i]
+	}
+	switch name {
+	case "":
+	case "*":
+		result = append(result, Segment{Kind: AnyKey})
+	case "**":
+		result = append(result, Segment{Kind: Recursive})
+	default:
+		if strings.ContainsAny(name, "]*") {
+			return nil, &SyntaxError{Path: path, Offset: offset, Msg: "invalid name " + name}
+		}
+		result = append(result, Segment{Kind: Key, Key: name})
+	}
+
+	rest := part[len(name):]
+	for rest != "" {
+		pos := offset + len(part) - len(rest)
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, &SyntaxError{Path: path, Offset: pos, Msg: "expected index"}
+		}
+		idx := rest[1:end]
+		if idx == "*" {
+			result = append(result, Segment{Kind: AnyIndex})
+		} else {
+			i, err := strconv.Atoi(idx)
+			if err != nil || i < 0 {
+				return nil, &SyntaxError{Path: path, Offset: pos, Msg: "invalid index " + idx}
+			}
+			result = append(result, Segment{Kind: Index, Index: i})
+		}
+		rest = rest[end+1:]
+	}
+	return result, nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (p Path) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (p *Path) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
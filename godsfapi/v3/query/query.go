@@ -0,0 +1,393 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+)
+
+var (
+	// ErrNoSuchKey is returned if a key does not exist
+	ErrNoSuchKey = errors.New("No such key")
+	// ErrIndexOutOfRange is returned if a list index does not exist
+	ErrIndexOutOfRange = errors.New("Index out of range")
+	// ErrNotAnObject is returned if a key is applied to a value without keys
+	ErrNotAnObject = errors.New("Not an object")
+	// ErrNotAList is returned if an index is applied to a value that is no list
+	ErrNotAList = errors.New("Not a list")
+	// ErrNull is returned if a path continues below a null value
+	ErrNull = errors.New("Value is null")
+	// ErrWildcard is returned by Get if the path contains wildcards
+	ErrWildcard = errors.New("Path contains wildcards")
+)
+
+// PathError is returned if a path cannot be resolved
+type PathError struct {
+	// Path that was queried
+	Path Path
+	// Resolved is the part of the path that could be resolved
+	Resolved Path
+	// Err is the reason
+	Err error
+}
+
+func (e *PathError) Error() string {
+	if len(e.Resolved) >= len(e.Path) {
+		return fmt.Sprintf("Cannot resolve %s: %s", e.Path, e.Err)
+	}
+	return fmt.Sprintf("Cannot resolve %s in %s: %s", e.Segment(), e.Path, e.Err)
+}
+
+// Segment returns the segment that could not be resolved
+func (e *PathError) Segment() Segment {
+	if len(e.Resolved) < len(e.Path) {
+		return e.Path[len(e.Resolved)]
+	}
+	return Segment{}
+}
+
+// Unwrap returns the reason of this error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// Variant is implemented by object model types that hold one of several concrete
+// types like move.Kinematics. Paths below them are resolved against the concrete value.
+type Variant interface {
+	VariantValue() interface{}
+}
+
+// Match is a single value selected by a query
+type Match struct {
+	// Path of the value without wildcards
+	Path Path
+	// Value at the path
+	Value interface{}
+}
+
+// Query returns all values matching the given path. Values may be typed models
+// or data decoded from JSON.
+func Query(v interface{}, path string) ([]Match, error) {
+	p, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Query(v)
+}
+
+// Get returns the value at the given path which must not contain wildcards
+func Get(v interface{}, path string) (interface{}, error) {
+	p, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return p.Get(v)
+}
+
+// QueryJSON returns all values of the given JSON document matching the given path
+func QueryJSON(data []byte, path string) ([]Match, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return Query(v, path)
+}
+
+// GetJSON returns the value of the given JSON document at the given path
+func GetJSON(data []byte, path string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return Get(v, path)
+}
+
+// Query returns all values matching this path. Missing keys are reported as
+// PathError unless they follow a wildcard, in which case they are skipped.
+func (p Path) Query(v interface{}) ([]Match, error) {
+	result := make([]Match, 0)
+	if err := p.walk(reflect.ValueOf(v), 0, nil, true, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Get returns the value at this path which must not contain wildcards
+func (p Path) Get(v interface{}) (interface{}, error) {
+	if p.HasWildcard() {
+		return nil, &PathError{Path: p, Err: ErrWildcard}
+	}
+	matches, err := p.Query(v)
+	if err != nil {
+		return nil, err
+	}
+	return matches[0].Value, nil
+}
+
+// walk resolves the segments starting at i below the value v located at resolved
+func (p Path) walk(v reflect.Value, i int, resolved Path, strict bool, result *[]Match) error {
+	v = indirect(v)
+	if i == len(p) || p[i].Kind == Recursive {
+		var value interface{}
+		if v.IsValid() {
+			value = v.Interface()
+		}
+		*result = append(*result, Match{Path: resolved, Value: value})
+		return nil
+	}
+
+	fail := func(err error) error {
+		if strict {
+			return &PathError{Path: p, Resolved: resolved, Err: err}
+		}
+		return nil
+	}
+	if !v.IsValid() {
+		return fail(ErrNull)
+	}
+	if u, ok := v.Interface().(Variant); ok {
+		if v = indirect(reflect.ValueOf(u.VariantValue())); !v.IsValid() {
+			return fail(ErrNull)
+		}
+	}
+
+	s := p[i]
+	switch s.Kind {
+	case Key:
+		child, ok, err := lookupKey(v, s.Key)
+		if err != nil {
+			return fail(err)
+		}
+		if !ok {
+			return fail(ErrNoSuchKey)
+		}
+		seg := s
+		if isList(v) {
+			seg = Segment{Kind: Index, Index: child.index}
+		}
+		return p.walk(child.value, i+1, resolved.Append(seg), strict, result)
+	case Index:
+		if !isList(v) {
+			return fail(ErrNotAList)
+		}
+		if s.Index >= v.Len() {
+			return fail(ErrIndexOutOfRange)
+		}
+		return p.walk(v.Index(s.Index), i+1, resolved.Append(s), strict, result)
+	case AnyIndex:
+		if !isList(v) {
+			return fail(ErrNotAList)
+		}
+		return p.walkChildren(listChildren(v), i, resolved, result)
+	default:
+		children, err := allChildren(v)
+		if err != nil {
+			return fail(err)
+		}
+		return p.walkChildren(children, i, resolved, result)
+	}
+}
+
+// walkChildren continues a wildcard segment on all given children
+func (p Path) walkChildren(children []child, i int, resolved Path, result *[]Match) error {
+	for _, c := range children {
+		if err := p.walk(c.value, i+1, resolved.Append(c.segment), false, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// child is a value below an object or list
+type child struct {
+	segment Segment
+	index   int
+	value   reflect.Value
+}
+
+// indirect dereferences pointers and interfaces and returns an invalid value for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// lookupKey returns the child with the given key. Numeric keys select list items.
+func lookupKey(v reflect.Value, key string) (child, bool, error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range structFields(v.Type()) {
+			if f.name == key {
+				fv, ok := fieldByIndex(v, f.index)
+				return child{value: fv}, ok, nil
+			}
+		}
+		if raw, ok := extraFields(v)[key]; ok {
+			return child{value: decodeRaw(raw)}, true, nil
+		}
+		return child{}, false, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return child{}, false, ErrNotAnObject
+		}
+		mv := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		return child{value: mv}, mv.IsValid(), nil
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return child{}, false, ErrNotAnObject
+		}
+		if i < 0 || i >= v.Len() {
+			return child{}, false, ErrIndexOutOfRange
+		}
+		return child{index: i, value: v.Index(i)}, true, nil
+	default:
+		return child{}, false, ErrNotAnObject
+	}
+}
+
+// allChildren returns every field, map entry or list item of v
+func allChildren(v reflect.Value) ([]child, error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := structFields(v.Type())
+		result := make([]child, 0, len(fields))
+		for _, f := range fields {
+			if fv, ok := fieldByIndex(v, f.index); ok {
+				result = append(result, child{segment: Segment{Kind: Key, Key: f.name}, value: fv})
+			}
+		}
+		members := extraFields(v)
+		keys := make([]string, 0, len(members))
+		for k := range members {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result = append(result, child{segment: Segment{Kind: Key, Key: k}, value: decodeRaw(members[k])})
+		}
+		return result, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, ErrNotAnObject
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		result := make([]child, 0, len(keys))
+		for _, k := range keys {
+			result = append(result, child{segment: Segment{Kind: Key, Key: k.String()}, value: v.MapIndex(k)})
+		}
+		return result, nil
+	case reflect.Slice, reflect.Array:
+		return listChildren(v), nil
+	default:
+		return nil, ErrNotAnObject
+	}
+}
+
+func listChildren(v reflect.Value) []child {
+	result := make([]child, v.Len())
+	for i := range result {
+		result[i] = child{segment: Segment{Kind: Index, Index: i}, index: i, value: v.Index(i)}
+	}
+	return result
+}
+
+// field is a JSON-visible field of a struct
+type field struct {
+	name  string
+	index []int
+}
+
+var fieldCache sync.Map
+
+// structFields returns the JSON-visible fields of the given struct type
+// including those promoted from embedded structs
+func structFields(t reflect.Type) []field {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.([]field)
+	}
+	fields := collectFields(t, nil)
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+func collectFields(t reflect.Type, index []int) []field {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		idx := append(append([]int{}, index...), i)
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				result = append(result, collectFields(ft, idx)...)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			// Unexported field
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		result = append(result, field{name: name, index: idx})
+	}
+	return result
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but does not panic on nil embedded pointers
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 {
+			if v = indirect(v); !v.IsValid() {
+				return v, false
+			}
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+var extraType = reflect.TypeOf(extra.Fields{})
+
+// extraFields returns the unknown members retained by an object model type
+func extraFields(v reflect.Value) extra.Fields {
+	if f := v.FieldByName("Extra"); f.IsValid() && f.Type() == extraType {
+		return f.Interface().(extra.Fields)
+	}
+	return nil
+}
+
+// decodeRaw decodes a retained member or returns an invalid value if that fails
+func decodeRaw(raw json.RawMessage) reflect.Value {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(v)
+}
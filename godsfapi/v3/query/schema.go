@@ -0,0 +1,81 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package query
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// CheckType verifies that this path can select a value in instances of the given type.
+// Segments below maps, interface values and Variant types cannot be checked and are
+// always accepted.
+// If a wildcard is used the remaining path has to be valid for at least one child type.
+// Maps selected by a wildcard only accept another wildcard, because any key would
+// be valid otherwise.
+func (p Path) CheckType(t reflect.Type) error {
+	return p.checkType(t, 0, nil, false)
+}
+
+var variantType = reflect.TypeOf((*Variant)(nil)).Elem()
+
+func (p Path) checkType(t reflect.Type, i int, resolved Path, viaWildcard bool) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if i == len(p) || p[i].Kind == Recursive || t.Kind() == reflect.Interface || t.Implements(variantType) {
+		return nil
+	}
+
+	fail := func(err error) error {
+		return &PathError{Path: p, Resolved: resolved, Err: err}
+	}
+	s := p[i]
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := structFields(t)
+		switch s.Kind {
+		case Key:
+			for _, f := range fields {
+				if f.name == s.Key {
+					return p.checkType(t.FieldByIndex(f.index).Type, i+1, resolved.Append(s), false)
+				}
+			}
+			return fail(ErrNoSuchKey)
+		case AnyKey:
+			var err error
+			for _, f := range fields {
+				seg := Segment{Kind: Key, Key: f.name}
+				if err = p.checkType(t.FieldByIndex(f.index).Type, i+1, resolved.Append(seg), true); err == nil {
+					return nil
+				}
+			}
+			if err == nil {
+				err = fail(ErrNoSuchKey)
+			}
+			return err
+		default:
+			return fail(ErrNotAList)
+		}
+	case reflect.Map:
+		if s.Kind != Key && s.Kind != AnyKey {
+			return fail(ErrNotAList)
+		}
+		if viaWildcard && s.Kind != AnyKey {
+			return fail(ErrNoSuchKey)
+		}
+		return p.checkType(t.Elem(), i+1, resolved.Append(s), false)
+	case reflect.Slice, reflect.Array:
+		switch s.Kind {
+		case Index, AnyIndex, AnyKey:
+		case Key:
+			idx, err := strconv.Atoi(s.Key)
+			if err != nil || idx < 0 {
+				return fail(ErrNotAnObject)
+			}
+			s = Segment{Kind: Index, Index: idx}
+		}
+		return p.checkType(t.Elem(), i+1, resolved.Append(s), false)
+	default:
+		return fail(ErrNotAnObject)
+	}
+}
@@ -0,0 +1,11 @@
+/*
+Package recording provides means to record object model subscriptions to disk
+and to replay them later.
+
+A recording is stored as JSON lines. Each line holds one Entry consisting of the
+time it was received, its kind (full model or patch) and the raw JSON data as sent
+by DuetControlServer. A Replayer implements connection.ModelSubscription so code
+written against a connection.SubscribeConnection can run on recorded sessions.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package recording
@@ -0,0 +1,118 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection/initmessages"
+)
+
+// EntryKind represents the kind of a recorded entry
+type EntryKind string
+
+const (
+	// ModelEntry holds a full object model
+	ModelEntry EntryKind = "model"
+	// PatchEntry holds a partial object model update
+	PatchEntry = "patch"
+)
+
+// Entry is a single line of a recording
+type Entry struct {
+	// Time the data was received
+	Time time.Time `json:"time"`
+	// Kind of this entry
+	Kind EntryKind `json:"kind"`
+	// Data is the JSON data as sent by the server
+	Data json.RawMessage `json:"data"`
+}
+
+// Recorder writes entries of a subscription session
+type Recorder struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+	// FlushEachEntry writes every entry to the underlying io.Writer immediately
+	// so that as little as possible is lost on a crash
+	FlushEachEntry bool
+}
+
+// NewRecorder creates a new Recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		w:              bufio.NewWriter(w),
+		FlushEachEntry: true,
+	}
+}
+
+// WriteEntry writes a single entry
+func (r *Recorder) WriteEntry(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err = r.w.Write(b); err != nil {
+		return err
+	}
+	if err = r.w.WriteByte('\n'); err != nil {
+		return err
+	}
+	if r.FlushEachEntry {
+		return r.w.Flush()
+	}
+	return nil
+}
+
+// WriteModel writes a full object model received at the given time
+func (r *Recorder) WriteModel(t time.Time, model []byte) error {
+	return r.WriteEntry(Entry{Time: t, Kind: ModelEntry, Data: model})
+}
+
+// WritePatch writes an object model patch received at the given time
+func (r *Recorder) WritePatch(t time.Time, patch []byte) error {
+	return r.WriteEntry(Entry{Time: t, Kind: PatchEntry, Data: patch})
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Flush()
+}
+
+// Record writes the initial object model and every following update received
+// from the given connection until an error occurs. The connection must be established
+// but no data may have been received from it yet.
+// In initmessages.SubscriptionModeFull every update is recorded as ModelEntry.
+func (r *Recorder) Record(sc *connection.SubscribeConnection) error {
+	defer r.Flush()
+
+	m, err := sc.GetSerializedObjectModel()
+	if err != nil {
+		return err
+	}
+	if err = r.WriteModel(time.Now(), m); err != nil {
+		return err
+	}
+
+	for {
+		j, err := sc.GetSerializedObjectModel()
+		if err != nil {
+			return err
+		}
+		if sc.Mode == initmessages.SubscriptionModeFull {
+			err = r.WriteModel(time.Now(), j)
+		} else {
+			err = r.WritePatch(time.Now(), j)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
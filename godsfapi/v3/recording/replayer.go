@@ -0,0 +1,194 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+)
+
+// ErrClosed is returned if a Replayer is used after it was closed
+var ErrClosed = errors.New("Replayer is closed")
+
+// UnexpectedEntryError is returned if an entry does not match the requested kind
+type UnexpectedEntryError struct {
+	// Expected entry kind
+	Expected EntryKind
+	// Actual entry kind
+	Actual EntryKind
+}
+
+func (e *UnexpectedEntryError) Error() string {
+	return fmt.Sprintf("Unexpected entry of kind %s (expected %s)", e.Actual, e.Expected)
+}
+
+// Reader reads the entries of a recording one by one
+type Reader struct {
+	decoder *json.Decoder
+}
+
+// NewReader creates a new Reader for the given recording
+func NewReader(r io.Reader) *Reader {
+	return &Reader{decoder: json.NewDecoder(r)}
+}
+
+// Next returns the next entry or io.EOF at the end of the recording
+func (r *Reader) Next() (*Entry, error) {
+	e := &Entry{}
+	if err := r.decoder.Decode(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Replayer feeds a recording back via the same API as connection.SubscribeConnection.
+// By default entries are returned with the delays they were originally received with.
+type Replayer struct {
+	// Speed is the playback speed factor where 1 is the original speed,
+	// 2 is twice as fast and 0 (or less) replays without any delays
+	Speed float64
+	// Stepwise makes every entry after the first one wait for a call to Step
+	Stepwise bool
+
+	r      *Reader
+	c      io.Closer
+	steps  chan struct{}
+	closed chan struct{}
+	once   sync.Once
+
+	started       bool
+	lastRecorded  time.Time
+	lastDelivered time.Time
+}
+
+// NewReplayer creates a new Replayer for the given recording at original speed
+func NewReplayer(r io.Reader) *Replayer {
+	rp := &Replayer{
+		Speed:  1,
+		r:      NewReader(r),
+		steps:  make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	if c, ok := r.(io.Closer); ok {
+		rp.c = c
+	}
+	return rp
+}
+
+// OpenReplayer opens the given recording file for replay
+func OpenReplayer(fileName string) (*Replayer, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(f), nil
+}
+
+// Step releases the next entry in Stepwise mode. It blocks until the entry
+// is requested and returns false if the Replayer was closed.
+func (rp *Replayer) Step() bool {
+	select {
+	case rp.steps <- struct{}{}:
+		return true
+	case <-rp.closed:
+		return false
+	}
+}
+
+// Next returns the next entry once it is due
+func (rp *Replayer) Next() (*Entry, error) {
+	select {
+	case <-rp.closed:
+		return nil, ErrClosed
+	default:
+	}
+
+	e, err := rp.r.Next()
+	if err != nil {
+		return nil, err
+	}
+	if !rp.started {
+		rp.started = true
+		rp.lastRecorded = e.Time
+		rp.lastDelivered = time.Now()
+		return e, nil
+	}
+
+	if rp.Stepwise {
+		select {
+		case <-rp.steps:
+		case <-rp.closed:
+			return nil, ErrClosed
+		}
+		rp.lastDelivered = time.Now()
+	} else if rp.Speed > 0 {
+		due := rp.lastDelivered.Add(time.Duration(float64(e.Time.Sub(rp.lastRecorded)) / rp.Speed))
+		if d := time.Until(due); d > 0 {
+			t := time.NewTimer(d)
+			select {
+			case <-t.C:
+			case <-rp.closed:
+				t.Stop()
+				return nil, ErrClosed
+			}
+		}
+		rp.lastDelivered = due
+	} else {
+		rp.lastDelivered = time.Now()
+	}
+	rp.lastRecorded = e.Time
+	return e, nil
+}
+
+// GetSerializedObjectModel returns the next entry as full object model in JSON format
+func (rp *Replayer) GetSerializedObjectModel() (json.RawMessage, error) {
+	e, err := rp.Next()
+	if err != nil {
+		return nil, err
+	}
+	if e.Kind != ModelEntry {
+		return nil, &UnexpectedEntryError{Expected: ModelEntry, Actual: e.Kind}
+	}
+	return e.Data, nil
+}
+
+// GetMachineModel returns the next entry as full object model
+func (rp *Replayer) GetMachineModel() (*machine.MachineModel, error) {
+	b, err := rp.GetSerializedObjectModel()
+	if err != nil {
+		return nil, err
+	}
+	m := machine.NewMachineModel()
+	if err = json.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetMachineModelPatch returns the data of the next entry as JSON string.
+// For recordings made in initmessages.SubscriptionModeFull this is the full object model.
+func (rp *Replayer) GetMachineModelPatch() (string, error) {
+	e, err := rp.Next()
+	if err != nil {
+		return "", err
+	}
+	return string(e.Data), nil
+}
+
+// Close stops the replay and closes the underlying recording if applicable
+func (rp *Replayer) Close() error {
+	var err error
+	rp.once.Do(func() {
+		close(rp.closed)
+		if rp.c != nil {
+			err = rp.c.Close()
+		}
+	})
+	return err
+}
@@ -0,0 +1,12 @@
+/*
+Package resume builds scripts that resume a print after a power failure.
+
+RepRapFirmware saves the state of an interrupted print in a restore point and
+the position of the job file. Build turns them into a G-code script that heats
+up, restores the tool, fans, spindles and position and continues the job file
+at the saved position, with options for re-homing X and Y and priming the
+nozzle. Validate checks the restore point against the current tool and heater
+configuration before the script is run.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package resume
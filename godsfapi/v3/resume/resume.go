@@ -0,0 +1,393 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package resume
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// Default values for Options
+const (
+	// DefaultRestorePoint is the restore point RepRapFirmware saves when a print is paused
+	DefaultRestorePoint = 1
+	DefaultZLift        = 2.0
+	DefaultTravelSpeed  = 100.0
+	DefaultPrimeSpeed   = 5.0
+	DefaultLaserMaxPwm  = 255.0
+)
+
+// Options control the generated script
+type Options struct {
+	// RestorePoint is the index of the restore point to resume from
+	RestorePoint int
+	// HomeXY homes X and Y instead of assuming that they did not move
+	HomeXY bool
+	// HeatFirst waits for all temperatures before the axes are touched
+	HeatFirst bool
+	// PrimeLength is the amount of filament to extrude before resuming (in mm)
+	PrimeLength float64
+	// PrimeSpeed is the extrusion speed for priming (in mm/s)
+	PrimeSpeed float64
+	// RelativeExtrusion tells if the job uses relative extrusion (M83). If it is nil
+	// the mode of the File channel is used, which is lost after a power failure.
+	RelativeExtrusion *bool
+	// ZLift is how far above the restore point the head travels (in mm)
+	ZLift float64
+	// TravelSpeed is the speed of the moves to the restore point (in mm/s)
+	TravelSpeed float64
+	// LaserMaxPwm is the S value of full laser power as configured by M452 R
+	LaserMaxPwm float64
+	// PowerFail must be set if the job was interrupted by a power failure.
+	// The relative Z lift and retraction of the power fail script are undone then.
+	PowerFail bool
+}
+
+// DefaultOptions returns the default options
+func DefaultOptions() Options {
+	return Options{
+		RestorePoint: DefaultRestorePoint,
+		HomeXY:       true,
+		HeatFirst:    true,
+		PrimeSpeed:   DefaultPrimeSpeed,
+		ZLift:        DefaultZLift,
+		TravelSpeed:  DefaultTravelSpeed,
+		LaserMaxPwm:  DefaultLaserMaxPwm,
+	}
+}
+
+// ValidationError lists the problems found by Validate
+type ValidationError struct {
+	// Problems found in the restore point or the configuration
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "Cannot resume: " + strings.Join(e.Problems, ", ")
+}
+
+// restorePoint returns the selected restore point
+func restorePoint(m *machine.MachineModel, opts Options) (*state.RestorePoint, error) {
+	if opts.RestorePoint < 0 || opts.RestorePoint >= len(m.State.RestorePoints) {
+		return nil, fmt.Errorf("Restore point %d does not exist", opts.RestorePoint)
+	}
+	return &m.State.RestorePoints[opts.RestorePoint], nil
+}
+
+// relativeExtrusion returns whether the job uses relative extrusion and false if that is unknown
+func relativeExtrusion(m *machine.MachineModel, opts Options) (relative, ok bool) {
+	if opts.RelativeExtrusion != nil {
+		return *opts.RelativeExtrusion, true
+	}
+	for _, ic := range m.Inputs {
+		if ic.Name == types.File {
+			return ic.DrivesRelative, true
+		}
+	}
+	return false, false
+}
+
+// jobFile returns the file of the interrupted job
+func jobFile(m *machine.MachineModel) string {
+	if m.Job.File.FileName != "" {
+		return m.Job.File.FileName
+	}
+	return m.Job.LastFileName
+}
+
+// Validate checks that the restore point matches the current configuration
+func Validate(m *machine.MachineModel, opts Options) error {
+	rp, err := restorePoint(m, opts)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	if jobFile(m) == "" {
+		problems = append(problems, "job file is unknown")
+	}
+	if m.Job.FilePosition == nil {
+		problems = append(problems, "file position is unknown")
+	}
+	if _, ok := relativeExtrusion(m, opts); !ok {
+		problems = append(problems, "extrusion mode is unknown")
+	}
+	if len(rp.Coords) > len(m.Move.Axes) {
+		problems = append(problems, fmt.Sprintf("restore point has %d coordinates but %d axes are configured", len(rp.Coords), len(m.Move.Axes)))
+	}
+	if len(rp.Coords) < 3 {
+		problems = append(problems, "restore point lacks X, Y or Z")
+	}
+	if len(rp.SpindleSpeeds) > len(m.Spindles) {
+		for i := len(m.Spindles); i < len(rp.SpindleSpeeds); i++ {
+			if rp.SpindleSpeeds[i] != 0 {
+				problems = append(problems, fmt.Sprintf("spindle %d is not configured", i))
+			}
+		}
+	}
+	if rp.LaserPwm != nil && *rp.LaserPwm > 0 && m.State.MachineMode != state.Laser {
+		problems = append(problems, "laser power is set but the machine is not in laser mode")
+	}
+
+	if rp.ToolNumber >= 0 {
+		t := findTool(m, rp.ToolNumber)
+		if t < 0 {
+			problems = append(problems, fmt.Sprintf("tool %d does not exist", rp.ToolNumber))
+		} else {
+			tool := &m.Tools[t]
+			for i, h := range tool.Heaters {
+				if h < 0 || int(h) >= len(m.Heat.Heaters) {
+					problems = append(problems, fmt.Sprintf("heater %d of tool %d does not exist", h, rp.ToolNumber))
+					continue
+				}
+				heater := &m.Heat.Heaters[h]
+				if heater.Sensor < 0 {
+					problems = append(problems, fmt.Sprintf("heater %d has no sensor", h))
+				}
+				if heater.State != nil && (*heater.State == heat.Fault || *heater.State == heat.Offline) {
+					problems = append(problems, fmt.Sprintf("heater %d is %s", h, *heater.State))
+				}
+				if i < len(tool.Active) && heater.Max > 0 && tool.Active[i] > heater.Max {
+					problems = append(problems, fmt.Sprintf("active temperature of heater %d exceeds its maximum", h))
+				}
+			}
+			if opts.PrimeLength > 0 && len(tool.Extruders) == 0 {
+				problems = append(problems, fmt.Sprintf("tool %d cannot be primed because it has no extruders", rp.ToolNumber))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// findTool returns the index of the tool with the given number or -1
+func findTool(m *machine.MachineModel, number int64) int {
+	for i, t := range m.Tools {
+		if t.Number == number {
+			return i
+		}
+	}
+	return -1
+}
+
+// Script is a generated resume script
+type Script struct {
+	// Lines of G-code
+	Lines []string
+}
+
+func (s *Script) add(format string, args ...interface{}) {
+	s.Lines = append(s.Lines, fmt.Sprintf(format, args...))
+}
+
+// Build validates the restore point and generates the resume script.
+// If opts.PowerFail is set, the head is assumed to be raised and the filament to be
+// retracted by the relative G0/G1 moves of state.PowerFailScript. Absolute moves of
+// that script cannot be undone because the position they started from is unknown.
+func Build(m *machine.MachineModel, opts Options) (*Script, error) {
+	if err := Validate(m, opts); err != nil {
+		return nil, err
+	}
+	rp, _ := restorePoint(m, opts)
+	var lift, retraction float64
+	if opts.PowerFail {
+		lift, retraction = powerFailMoves(m.State.PowerFailScript)
+	}
+	s := &Script{}
+	file := jobFile(m)
+	s.add("; Resume %q from restore point %d", file, opts.RestorePoint)
+	s.add("G21")
+
+	// Temperatures
+	for i, h := range m.Heat.Beds {
+		if h >= 0 && int(h) < len(m.Heat.Heaters) && m.Heat.Heaters[h].Active > 0 {
+			s.add("M140 P%d S%g", i, m.Heat.Heaters[h].Active)
+		}
+	}
+	for i, h := range m.Heat.ChamberHeaters {
+		if h >= 0 && int(h) < len(m.Heat.Heaters) && m.Heat.Heaters[h].Active > 0 {
+			s.add("M141 P%d S%g", i, m.Heat.Heaters[h].Active)
+		}
+	}
+	if t := findTool(m, rp.ToolNumber); t >= 0 && len(m.Tools[t].Active) > 0 {
+		code := fmt.Sprintf("G10 P%d S%s", rp.ToolNumber, joinFloats(m.Tools[t].Active))
+		if len(m.Tools[t].Standby) > 0 {
+			code += " R" + joinFloats(m.Tools[t].Standby)
+		}
+		s.Lines = append(s.Lines, code)
+	}
+	if opts.HeatFirst {
+		s.add("T%d P0", rp.ToolNumber)
+		s.add("M116")
+	}
+
+	// Position. Z cannot be homed without touching the print so it is assumed
+	// that it did not move.
+	axes := m.Move.Axes
+	var setPos []string
+	for i, c := range rp.Coords {
+		letter := axes[i].Letter
+		if opts.HomeXY && (letter == "X" || letter == "Y") {
+			continue
+		}
+		if letter == "Z" {
+			c += lift
+		}
+		setPos = append(setPos, fmt.Sprintf("%s%.3f", letter, c))
+	}
+	s.add("G92 %s", strings.Join(setPos, " "))
+	if opts.HomeXY {
+		s.add("G91")
+		s.add("G1 H2 Z%g F%g", opts.ZLift, opts.TravelSpeed*60)
+		s.add("G90")
+		s.add("G28 X Y")
+	}
+	if !opts.HeatFirst {
+		s.add("T%d P0", rp.ToolNumber)
+		s.add("M116")
+	}
+
+	// Fans and spindles
+	for i, f := range m.Fans {
+		if f.RequestedValue > 0 {
+			s.add("M106 P%d S%.2f", i, f.RequestedValue)
+		}
+	}
+	for i, speed := range rp.SpindleSpeeds {
+		if speed > 0 {
+			s.add("M3 P%d S%g", i, speed)
+		} else if speed < 0 {
+			s.add("M4 P%d S%g", i, -speed)
+		}
+	}
+
+	// Job file
+	s.add("M23 %q", file)
+	s.add("M26 S%d", *m.Job.FilePosition)
+
+	// Moves to the restore point. The nozzle is primed above it so that nothing
+	// is extruded onto the print.
+	travel := opts.TravelSpeed * 60
+	s.add("G0 F%g Z%.3f", travel, rp.Coords[2]+opts.ZLift)
+	s.add("G0 F%g X%.3f Y%.3f", travel, rp.Coords[0], rp.Coords[1])
+	if prime := opts.PrimeLength + retraction; prime > 0 {
+		s.add("M83")
+		s.add("G1 E%.3f F%g", prime, opts.PrimeSpeed*60)
+	}
+	if relative, _ := relativeExtrusion(m, opts); relative {
+		s.add("M83")
+	} else {
+		s.add("M82")
+	}
+	s.add("G92 E%.5f", rp.ExtruderPos)
+	s.add("G0 F%g Z%.3f", travel, rp.Coords[2])
+	feed := fmt.Sprintf("G1 F%.1f", rp.FeedRate*60)
+	if rp.IoBits != nil {
+		feed += fmt.Sprintf(" P%d", *rp.IoBits)
+	}
+	if rp.LaserPwm != nil {
+		feed += fmt.Sprintf(" S%g", math.Round(*rp.LaserPwm*opts.LaserMaxPwm*100)/100)
+	}
+	s.Lines = append(s.Lines, feed)
+	s.add("M24")
+	return s, nil
+}
+
+// powerFailMoves returns the Z lift and the retraction of the relative moves
+// in a power fail script. Commands may be separated by spaces like in M911.
+func powerFailMoves(script string) (lift, retraction float64) {
+	relative, relativeExtrusion, move := false, false, false
+	for _, word := range strings.Fields(strings.ToUpper(script)) {
+		if i := strings.IndexByte(word, ';'); i >= 0 {
+			word = word[:i]
+		}
+		if word == "" {
+			continue
+		}
+		switch word[0] {
+		case 'G', 'M', 'T':
+			move = false
+			switch word {
+			case "G0", "G1":
+				move = true
+			case "G90":
+				relative, relativeExtrusion = false, false
+			case "G91":
+				relative, relativeExtrusion = true, true
+			case "M82":
+				relativeExtrusion = false
+			case "M83":
+				relativeExtrusion = true
+			}
+		case 'Z', 'E':
+			if !move {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.Split(word[1:], ":")[0], 64)
+			if err != nil {
+				continue
+			}
+			if word[0] == 'Z' && relative {
+				lift += v
+			} else if word[0] == 'E' && relativeExtrusion {
+				retraction -= v
+			}
+		}
+	}
+	return
+}
+
+// joinFloats formats temperatures like G10 expects them
+func joinFloats(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%g", v)
+	}
+	return strings.Join(parts, ":")
+}
+
+// WriteTo writes the script, e.g. to a file that is run using M98
+func (s *Script) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, line := range s.Lines {
+		c, err := bw.WriteString(line + "\n")
+		n += int64(c)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// Run performs the script code by code and stops at the first error
+func (s *Script) Run(p CodePerformer, channel types.CodeChannel) error {
+	for _, line := range s.Lines {
+		if strings.HasPrefix(line, ";") {
+			continue
+		}
+		reply, err := p.PerformSimpleCode(line, channel)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(reply, "Error") {
+			return fmt.Errorf("%s: %s", line, strings.TrimSpace(reply))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package resume
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+)
+
+const model = `{
+	"state":{"powerFailScript":"M913 X0 Y0 G91 M83 G1 Z3 E-5 F1000",
+		"restorePoints":[{},{"coords":[10,20,5],"extruderPos":123.4,"toolNumber":0,"feedRate":50}]},
+	"inputs":[{"name":"HTTP","drivesRelative":false},{"name":"File","drivesRelative":true}],
+	"job":{"file":{"fileName":"0:/gcodes/a.gcode"},"filePosition":1234},
+	"move":{"axes":[{"letter":"X"},{"letter":"Y"},{"letter":"Z"}]},
+	"heat":{"beds":[1],"heaters":[{"active":200,"sensor":0,"state":"active"},{"active":60,"sensor":1,"state":"active"}]},
+	"fans":[{"requestedValue":0.5}],
+	"tools":[{"number":0,"heaters":[0],"extruders":[0],"active":[200]}]}`
+
+func parseModel(t *testing.T, patches ...string) *machine.MachineModel {
+	t.Helper()
+	m := machine.NewMachineModel()
+	for _, p := range append([]string{model}, patches...) {
+		if err := m.UpdateFromJson([]byte(p)); err != nil {
+			t.Fatalf("Failed to parse model: %v", err)
+		}
+	}
+	return m
+}
+
+func TestBuild(t *testing.T) {
+	absolute := false
+	tests := []struct {
+		name     string
+		patch    string
+		opts     func(*Options)
+		expected []string
+	}{
+		{
+			name: "pause",
+			opts: func(o *Options) { o.PrimeLength = 2 },
+			expected: []string{
+				`; Resume "0:/gcodes/a.gcode" from restore point 1`,
+				"G21",
+				"M140 P0 S60",
+				"G10 P0 S200",
+				"T0 P0",
+				"M116",
+				"G92 Z5.000",
+				"G91",
+				"G1 H2 Z2 F6000",
+				"G90",
+				"G28 X Y",
+				"M106 P0 S0.50",
+				`M23 "0:/gcodes/a.gcode"`,
+				"M26 S1234",
+				"G0 F6000 Z7.000",
+				"G0 F6000 X10.000 Y20.000",
+				"M83",
+				"G1 E2.000 F300",
+				"M83",
+				"G92 E123.40000",
+				"G0 F6000 Z5.000",
+				"G1 F3000.0",
+				"M24",
+			},
+		},
+		{
+			name:  "power failure with absolute extrusion and standby temperature",
+			patch: `{"tools":[{"standby":[150]}]}`,
+			opts: func(o *Options) {
+				o.PowerFail = true
+				o.HomeXY = false
+				o.RelativeExtrusion = &absolute
+			},
+			expected: []string{
+				`; Resume "0:/gcodes/a.gcode" from restore point 1`,
+				"G21",
+				"M140 P0 S60",
+				"G10 P0 S200 R150",
+				"T0 P0",
+				"M116",
+				"G92 X10.000 Y20.000 Z8.000",
+				"M106 P0 S0.50",
+				`M23 "0:/gcodes/a.gcode"`,
+				"M26 S1234",
+				"G0 F6000 Z7.000",
+				"G0 F6000 X10.000 Y20.000",
+				"M83",
+				"G1 E5.000 F300",
+				"M82",
+				"G92 E123.40000",
+				"G0 F6000 Z5.000",
+				"G1 F3000.0",
+				"M24",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patches []string
+			if tt.patch != "" {
+				patches = append(patches, tt.patch)
+			}
+			m := parseModel(t, patches...)
+			opts := DefaultOptions()
+			tt.opts(&opts)
+			s, err := Build(m, opts)
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			if got, want := strings.Join(s.Lines, "\n"), strings.Join(tt.expected, "\n"); got != want {
+				t.Errorf("Unexpected script:\n%s\nexpected:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		problem string
+	}{
+		{"unknown extrusion mode", `{"inputs":[{"name":"HTTP"},{"name":"USB"}]}`, "extrusion mode is unknown"},
+		{"unknown file position", `{"job":{"filePosition":null}}`, "file position is unknown"},
+		{"missing tool", `{"state":{"restorePoints":[{},{"toolNumber":3}]}}`, "tool 3 does not exist"},
+		{"faulty heater", `{"heat":{"heaters":[{"state":"fault"}]}}`, "heater 0 is fault"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(parseModel(t, tt.patch), DefaultOptions())
+			var ve *ValidationError
+			if !errors.As(err, &ve) || !strings.Contains(ve.Error(), tt.problem) {
+				t.Errorf("Validate returned %v, expected %q", err, tt.problem)
+			}
+		})
+	}
+}
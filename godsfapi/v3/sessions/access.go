@@ -0,0 +1,122 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package sessions
+
+import (
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/usersessions"
+)
+
+// Category classifies codes by what they may change
+type Category int
+
+const (
+	// Harmless codes only report information and may be sent by every session
+	Harmless Category = iota
+	// Motion codes move axes or extruders, drive outputs or change the tool
+	Motion
+	// Heater codes change temperatures, sensors or heater settings
+	Heater
+	// File codes start, stop or modify jobs, macros, files or the firmware
+	File
+	// Other codes change the configuration or are not known to be harmless
+	Other
+)
+
+var (
+	// queryMCodes are M-codes that only report information
+	queryMCodes = map[int64]bool{20: true, 27: true, 31: true, 36: true, 39: true, 105: true, 114: true, 115: true, 119: true, 122: true, 409: true}
+	// motionGCodes are G-codes that cause moves or change positions
+	motionGCodes = map[int64]bool{0: true, 1: true, 2: true, 3: true, 28: true, 29: true, 30: true, 32: true, 38: true, 92: true}
+	// motionMCodes are M-codes that cause moves, power the motors or drive outputs
+	motionMCodes = map[int64]bool{3: true, 4: true, 5: true, 17: true, 18: true, 42: true, 84: true, 280: true, 564: true, 675: true}
+	// heaterMCodes are M-codes that change heaters
+	heaterMCodes = map[int64]bool{104: true, 109: true, 140: true, 141: true, 143: true, 190: true, 191: true, 301: true, 303: true, 307: true, 308: true, 568: true, 570: true, 950: true}
+	// fileMCodes are M-codes that affect jobs, macros, files or the firmware
+	fileMCodes = map[int64]bool{0: true, 1: true, 23: true, 24: true, 25: true, 26: true, 28: true, 29: true, 30: true, 32: true, 37: true, 98: true, 112: true, 226: true, 471: true, 559: true, 560: true, 997: true, 999: true}
+)
+
+// Classify returns the category of the given code. Only comments, echo and the
+// M-codes that query information are Harmless, unknown codes are Other.
+func Classify(code *commands.Code) Category {
+	switch code.Keyword {
+	case commands.None:
+	case commands.Abort:
+		return File
+	case commands.Echo:
+		return Harmless
+	default:
+		return Other
+	}
+	if code.Type == commands.Comment {
+		return Harmless
+	}
+	if code.MajorNumber == nil {
+		return Other
+	}
+	n := *code.MajorNumber
+	switch code.Type {
+	case commands.GCode:
+		if motionGCodes[n] {
+			return Motion
+		}
+		if n == 10 && (code.HasParameter("S") || code.HasParameter("R")) {
+			// G10 P S R sets tool temperatures
+			return Heater
+		}
+	case commands.MCode:
+		switch {
+		case queryMCodes[n]:
+			return Harmless
+		case motionMCodes[n]:
+			return Motion
+		case heaterMCodes[n]:
+			return Heater
+		case fileMCodes[n]:
+			return File
+		}
+	case commands.TCode:
+		return Motion
+	}
+	return Other
+}
+
+// Allowed returns false if the code comes from a read-only session and is not harmless.
+// Codes from connections that are not bound to a session are always allowed.
+func (m *Manager) Allowed(code *commands.Code) bool {
+	s, ok := m.ByConnection(code.SourceConnection)
+	if !ok || s.AccessLevel != usersessions.ReadOnly {
+		return true
+	}
+	return Classify(code) == Harmless
+}
+
+// CodeInterceptor receives codes and decides about them, e.g. a connection.InterceptConnection
+type CodeInterceptor interface {
+	ReceiveCode() (*commands.Code, error)
+	CancelCode() error
+	IgnoreCode() error
+}
+
+// Enforce cancels every code of the given interceptor that is not Allowed and
+// marks the session of every other code from a bound connection as active.
+// The interceptor should be connected in pre mode. Enforce returns when
+// receiving or answering a code fails.
+func (m *Manager) Enforce(ic CodeInterceptor) error {
+	for {
+		code, err := ic.ReceiveCode()
+		if err != nil {
+			return err
+		}
+		if !m.Allowed(code) {
+			err = ic.CancelCode()
+		} else {
+			if s, ok := m.ByConnection(code.SourceConnection); ok {
+				m.Touch(s.Key)
+			}
+			err = ic.IgnoreCode()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/usersessions"
+)
+
+// newCode creates a code with the given parameters that have no values
+func newCode(t commands.CodeType, major int64, letters ...string) *commands.Code {
+	c := commands.NewCode()
+	c.Type = t
+	c.MajorNumber = &major
+	for _, l := range letters {
+		c.Parameters = append(c.Parameters, *commands.NewSimpleCodeParameter(l, int64(0)))
+	}
+	return c
+}
+
+// fakeCommander hands out increasing session IDs
+type fakeCommander struct {
+	next int
+}
+
+func (f *fakeCommander) AddUserSession(access usersessions.AccessLevel, t usersessions.SessionType, origin string, originPort int) (int, error) {
+	f.next++
+	return f.next, nil
+}
+
+func (f *fakeCommander) RemoveUserSession(id int) (bool, error) {
+	return true, nil
+}
+
+func TestAccess(t *testing.T) {
+	comment := commands.NewCode()
+	comment.Type = commands.Comment
+	echo := commands.NewCode()
+	echo.Keyword = commands.Echo
+	abort := commands.NewCode()
+	abort.Keyword = commands.Abort
+	set := commands.NewCode()
+	set.Keyword = commands.Set
+
+	tests := []struct {
+		name     string
+		code     *commands.Code
+		category Category
+	}{
+		{"comment", comment, Harmless},
+		{"echo", echo, Harmless},
+		{"M20", newCode(commands.MCode, 20), Harmless},
+		{"M105", newCode(commands.MCode, 105), Harmless},
+		{"M122", newCode(commands.MCode, 122), Harmless},
+		{"M409", newCode(commands.MCode, 409, "K"), Harmless},
+		{"G1", newCode(commands.GCode, 1, "X"), Motion},
+		{"G92", newCode(commands.GCode, 92, "Z"), Motion},
+		{"M42", newCode(commands.MCode, 42, "P", "S"), Motion},
+		{"M280", newCode(commands.MCode, 280, "P", "S"), Motion},
+		{"M564 H0", newCode(commands.MCode, 564, "H"), Motion},
+		{"T1", newCode(commands.TCode, 1), Motion},
+		{"G10 P0 S200", newCode(commands.GCode, 10, "P", "S"), Heater},
+		{"M301", newCode(commands.MCode, 301, "H"), Heater},
+		{"M308", newCode(commands.MCode, 308, "S"), Heater},
+		{"M570", newCode(commands.MCode, 570, "H"), Heater},
+		{"M950", newCode(commands.MCode, 950, "H"), Heater},
+		{"M24", newCode(commands.MCode, 24), File},
+		{"M112", newCode(commands.MCode, 112), File},
+		{"M997", newCode(commands.MCode, 997), File},
+		{"M999", newCode(commands.MCode, 999), File},
+		{"abort", abort, File},
+		{"set", set, Other},
+		{"G10 L2", newCode(commands.GCode, 10, "L", "P"), Other},
+		{"M500", newCode(commands.MCode, 500), Other},
+		{"unknown M-code", newCode(commands.MCode, 4242), Other},
+	}
+
+	m := NewManager(&fakeCommander{}, time.Minute)
+	if _, err := m.Open("reader", usersessions.ReadOnly, usersessions.HTTP, "10.0.0.2", 80); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if _, err := m.Open("writer", usersessions.ReadWrite, usersessions.HTTP, "10.0.0.3", 80); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if err := m.Bind("reader", 1); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if err := m.Bind("writer", 2); err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c := Classify(tt.code); c != tt.category {
+				t.Errorf("Classify returned %d, expected %d", c, tt.category)
+			}
+			for conn, expected := range map[int64]bool{1: tt.category == Harmless, 2: true, 3: true} {
+				tt.code.SourceConnection = conn
+				if allowed := m.Allowed(tt.code); allowed != expected {
+					t.Errorf("Allowed from connection %d returned %t, expected %t", conn, allowed, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+/*
+Package sessions manages the user sessions of remote clients.
+
+AddUserSession and RemoveUserSession register single sessions with the control
+server. A Manager keeps track of the sessions it created for its own clients,
+expires them after a period of inactivity and registers them again after the
+connection to the control server was re-established. The command connections
+of a client can be bound to its session so that Enforce can cancel every code
+of a read-only session that does more than query information.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package sessions
@@ -0,0 +1,289 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/usersessions"
+)
+
+// ErrUnknownSession is returned if there is no session for the given key
+var ErrUnknownSession = errors.New("Unknown session")
+
+// SessionCommander registers user sessions, e.g. a connection.CommandConnection
+type SessionCommander interface {
+	AddUserSession(access usersessions.AccessLevel, t usersessions.SessionType, origin string, originPort int) (int, error)
+	RemoveUserSession(id int) (bool, error)
+}
+
+// Session is a user session created by a Manager
+type Session struct {
+	// Key identifies the client this session belongs to
+	Key string
+	// Id of the user session in the object model
+	Id int
+	// AccessLevel of this session
+	AccessLevel usersessions.AccessLevel
+	// SessionType of this session
+	SessionType usersessions.SessionType
+	// Origin of this session, e.g. the remote IP address
+	Origin string
+	// OriginPort of this session, e.g. the remote port
+	OriginPort int
+	// Connections are the IDs of the connections whose codes belong to this session
+	Connections []int64
+	// Created is when this session was opened
+	Created time.Time
+	// LastActive is when this session was last used
+	LastActive time.Time
+}
+
+// clone returns a copy that does not share the connection list
+func (s *Session) clone() Session {
+	c := *s
+	c.Connections = append([]int64(nil), s.Connections...)
+	return c
+}
+
+// Manager creates, tracks and expires user sessions
+type Manager struct {
+	// Timeout after which inactive sessions expire or 0 to keep them
+	Timeout time.Duration
+
+	mu        sync.Mutex
+	commander SessionCommander
+	sessions  map[string]*Session
+	byConn    map[int64]*Session
+}
+
+// NewManager creates a new Manager registering sessions using the given commander
+func NewManager(c SessionCommander, timeout time.Duration) *Manager {
+	return &Manager{
+		Timeout:   timeout,
+		commander: c,
+		sessions:  make(map[string]*Session),
+		byConn:    make(map[int64]*Session),
+	}
+}
+
+// Open returns the session of the given client and creates it if necessary
+func (m *Manager) Open(key string, access usersessions.AccessLevel, t usersessions.SessionType, origin string, originPort int) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if s, ok := m.sessions[key]; ok {
+		if s.AccessLevel == access && s.SessionType == t && s.Origin == origin && s.OriginPort == originPort {
+			s.LastActive = now
+			return s.clone(), nil
+		}
+		if err := m.remove(s); err != nil {
+			return Session{}, err
+		}
+	}
+
+	id, err := m.commander.AddUserSession(access, t, origin, originPort)
+	if err != nil {
+		return Session{}, err
+	}
+	s := &Session{
+		Key:         key,
+		Id:          id,
+		AccessLevel: access,
+		SessionType: t,
+		Origin:      origin,
+		OriginPort:  originPort,
+		Created:     now,
+		LastActive:  now,
+	}
+	m.sessions[key] = s
+	return s.clone(), nil
+}
+
+// Get returns the session of the given client
+func (m *Manager) Get(key string) (Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[key]
+	if !ok {
+		return Session{}, false
+	}
+	return s.clone(), true
+}
+
+// Sessions returns all sessions ordered by key
+func (m *Manager) Sessions() []Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	result := make([]Session, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		result = append(result, s.clone())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// Touch marks the session of the given client as active
+func (m *Manager) Touch(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSession, key)
+	}
+	s.LastActive = time.Now()
+	return nil
+}
+
+// Close removes the session of the given client
+func (m *Manager) Close(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSession, key)
+	}
+	return m.remove(s)
+}
+
+// remove unregisters a session. Must be called with mu held.
+func (m *Manager) remove(s *Session) error {
+	delete(m.sessions, s.Key)
+	for _, c := range s.Connections {
+		delete(m.byConn, c)
+	}
+	if _, err := m.commander.RemoveUserSession(s.Id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Bind assigns the codes of the connection with the given ID to the session of
+// the given client, see connection.BaseConnection.Id
+func (m *Manager) Bind(key string, connectionId int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSession, key)
+	}
+	if old, ok := m.byConn[connectionId]; ok {
+		old.Connections = removeId(old.Connections, connectionId)
+	}
+	s.Connections = append(s.Connections, connectionId)
+	m.byConn[connectionId] = s
+	return nil
+}
+
+// Unbind removes the connection with the given ID from its session
+func (m *Manager) Unbind(connectionId int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.byConn[connectionId]; ok {
+		s.Connections = removeId(s.Connections, connectionId)
+		delete(m.byConn, connectionId)
+	}
+}
+
+func removeId(ids []int64, id int64) []int64 {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// ByConnection returns the session the given connection is bound to
+func (m *Manager) ByConnection(connectionId int64) (Session, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.byConn[connectionId]
+	if !ok {
+		return Session{}, false
+	}
+	return s.clone(), true
+}
+
+// Expire removes all sessions that have been inactive for longer than Timeout
+// and returns them
+func (m *Manager) Expire(now time.Time) ([]Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Timeout <= 0 {
+		return nil, nil
+	}
+	var expired []Session
+	var firstErr error
+	for _, s := range m.sessions {
+		if now.Sub(s.LastActive) > m.Timeout {
+			expired = append(expired, s.clone())
+			if err := m.remove(s); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Key < expired[j].Key })
+	return expired, firstErr
+}
+
+// RunExpiry calls Expire in the given interval until ctx is done
+func (m *Manager) RunExpiry(ctx context.Context, interval time.Duration) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-t.C:
+			if _, err := m.Expire(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Restore registers the tracked sessions again using a new commander, e.g. after
+// the control server was restarted. Sessions that are still listed in existing,
+// e.g. MachineModel.UserSessions, are kept. Connection bindings are dropped
+// because reconnected clients get new connection IDs.
+func (m *Manager) Restore(c SessionCommander, existing []usersessions.UserSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.commander = c
+	m.byConn = make(map[int64]*Session)
+
+	keys := make([]string, 0, len(m.sessions))
+	for k := range m.sessions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		s := m.sessions[k]
+		s.Connections = nil
+		if containsSession(existing, s) {
+			continue
+		}
+		id, err := c.AddUserSession(s.AccessLevel, s.SessionType, s.Origin, s.OriginPort)
+		if err != nil {
+			return err
+		}
+		s.Id = id
+	}
+	return nil
+}
+
+// containsSession returns true if the given session is still registered
+func containsSession(existing []usersessions.UserSession, s *Session) bool {
+	for _, us := range existing {
+		if us.Id == int64(s.Id) && us.AccessLevel == s.AccessLevel && us.SessionType == s.SessionType &&
+			us.Origin == s.Origin && us.OriginId == s.OriginPort {
+			return true
+		}
+	}
+	return false
+}
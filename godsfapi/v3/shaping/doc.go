@@ -0,0 +1,11 @@
+/*
+Package shaping calculates the response of the input shapers of RepRapFirmware.
+
+A Shaper holds the impulses of a configured input shaper. It calculates the
+residual vibration at any frequency, the smoothing of the toolpath and the
+acceleration that keeps the smoothing within a limit. Suggest compares all
+shaper types for a measured resonance so that settings can be evaluated before
+they are applied using M593.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package shaping
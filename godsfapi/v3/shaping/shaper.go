@@ -0,0 +1,263 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package shaping
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/move"
+)
+
+const (
+	// DefaultDamping is the damping ratio used by M593 if none is given
+	DefaultDamping = 0.1
+	// DefaultMaxSmoothing is the toolpath smoothing considered acceptable (in mm)
+	DefaultMaxSmoothing = 0.12
+	// vibrationTolerance is the residual vibration the EI2 shaper is designed for
+	vibrationTolerance = 0.05
+)
+
+// Impulse is a single impulse of a shaper
+type Impulse struct {
+	// Time of the impulse (in s)
+	Time float64 `json:"time"`
+	// Amplitude of the impulse. The amplitudes of a shaper add up to 1.
+	Amplitude float64 `json:"amplitude"`
+}
+
+// Shaper is an input shaper with its impulses
+type Shaper struct {
+	// Type of the shaper
+	Type move.MoveInputShapingType `json:"type"`
+	// Frequency the shaper is tuned to (in Hz)
+	Frequency float64 `json:"frequency"`
+	// Damping ratio the shaper is tuned to
+	Damping float64 `json:"damping"`
+	// MinimumAcceleration used by DAA (in mm/s^2)
+	MinimumAcceleration float64 `json:"minimumAcceleration"`
+	// Impulses of the shaper. DAA and disabled shaping use a single impulse.
+	Impulses []Impulse `json:"impulses"`
+}
+
+// New creates a shaper of the given type
+// The type is matched case-insensitively because the firmware reports it in lower case.
+func New(t move.MoveInputShapingType, frequency, damping float64) (*Shaper, error) {
+	t = normalizeType(t)
+	s := &Shaper{Type: t, Frequency: frequency, Damping: damping}
+	if t == move.MoveInputShapingTypeNone || t == move.DAA {
+		s.Impulses = []Impulse{{Amplitude: 1}}
+		return s, nil
+	}
+	if frequency <= 0 {
+		return nil, fmt.Errorf("Invalid shaper frequency %g", frequency)
+	}
+	if damping < 0 || damping >= 1 {
+		return nil, fmt.Errorf("Invalid damping ratio %g", damping)
+	}
+
+	df := math.Sqrt(1 - damping*damping)
+	k := math.Exp(-damping * math.Pi / df)
+	td := 1 / (frequency * df)
+	var a, times []float64
+	switch t {
+	case move.ZVD:
+		a = []float64{1, 2 * k, k * k}
+		times = []float64{0, td / 2, td}
+	case move.ZVDD:
+		a = []float64{1, 3 * k, 3 * k * k, k * k * k}
+		times = []float64{0, td / 2, td, 1.5 * td}
+	case move.EI2:
+		v2 := vibrationTolerance * vibrationTolerance
+		x := math.Pow(v2*(math.Sqrt(1-v2)+1), 1.0/3)
+		a1 := (3*x*x + 2*x + 3*v2) / (16 * x)
+		a2 := (0.5 - a1) * k
+		a = []float64{a1, a2, a2 * k, a1 * k * k * k}
+		times = []float64{0, td / 2, td, 1.5 * td}
+	default:
+		return nil, fmt.Errorf("Unsupported shaper type %s", t)
+	}
+
+	var sum float64
+	for _, v := range a {
+		sum += v
+	}
+	for i := range a {
+		s.Impulses = append(s.Impulses, Impulse{Time: times[i], Amplitude: a[i] / sum})
+	}
+	return s, nil
+}
+
+// normalizeType converts a shaper type to the spelling of the move constants
+func normalizeType(t move.MoveInputShapingType) move.MoveInputShapingType {
+	if strings.EqualFold(string(t), string(move.MoveInputShapingTypeNone)) {
+		return move.MoveInputShapingTypeNone
+	}
+	return move.MoveInputShapingType(strings.ToUpper(string(t)))
+}
+
+// FromModel creates the shaper configured in the object model
+func FromModel(mis move.MoveInputShaping) (*Shaper, error) {
+	t := mis.Type
+	if t == "" {
+		t = move.MoveInputShapingTypeNone
+	}
+	s, err := New(t, mis.Frequency, mis.Damping)
+	if err != nil {
+		return nil, err
+	}
+	s.MinimumAcceleration = mis.MinimumAcceleration
+	return s, nil
+}
+
+// Vibration returns the residual vibration of a system with the given resonance
+// frequency and damping ratio relative to an unshaped move (0..1)
+func (s *Shaper) Vibration(frequency, damping float64) float64 {
+	omega := 2 * math.Pi * frequency
+	omegaD := omega * math.Sqrt(1-damping*damping)
+	end := s.Duration()
+	var c, sn float64
+	for _, i := range s.Impulses {
+		w := i.Amplitude * math.Exp(-damping*omega*(end-i.Time))
+		c += w * math.Cos(omegaD*i.Time)
+		sn += w * math.Sin(omegaD*i.Time)
+	}
+	return math.Hypot(c, sn)
+}
+
+// ResponsePoint is the residual vibration at a single frequency
+type ResponsePoint struct {
+	// Frequency in Hz
+	Frequency float64 `json:"frequency"`
+	// Vibration relative to an unshaped move (0..1)
+	Vibration float64 `json:"vibration"`
+}
+
+// Response calculates the residual vibration from min to max frequency (in Hz)
+func (s *Shaper) Response(min, max, step, damping float64) []ResponsePoint {
+	var result []ResponsePoint
+	if step <= 0 {
+		return result
+	}
+	for f := min; f <= max+step/2; f += step {
+		result = append(result, ResponsePoint{Frequency: f, Vibration: s.Vibration(f, damping)})
+	}
+	return result
+}
+
+// Duration returns the time between the first and the last impulse (in s)
+// which is added to every change of acceleration
+func (s *Shaper) Duration() float64 {
+	if len(s.Impulses) == 0 {
+		return 0
+	}
+	return s.Impulses[len(s.Impulses)-1].Time
+}
+
+// variance returns the variance of the impulse times (in s^2)
+func (s *Shaper) variance() float64 {
+	var mean, sq float64
+	for _, i := range s.Impulses {
+		mean += i.Amplitude * i.Time
+		sq += i.Amplitude * i.Time * i.Time
+	}
+	return sq - mean*mean
+}
+
+// Smoothing returns how far the shaped toolpath deviates from the commanded one
+// (in mm) after a change of acceleration by accel (in mm/s^2)
+func (s *Shaper) Smoothing(accel float64) float64 {
+	return accel * s.variance() / 2
+}
+
+// MaxAcceleration returns the highest acceleration (in mm/s^2) that keeps the
+// smoothing within maxSmoothing (in mm) or +Inf if the shaper does not smooth
+func (s *Shaper) MaxAcceleration(maxSmoothing float64) float64 {
+	v := s.variance()
+	if v <= 0 {
+		return math.Inf(1)
+	}
+	return 2 * maxSmoothing / v
+}
+
+// DAAAcceleration returns the acceleration (in mm/s^2) that DAA uses for a speed
+// change (in mm/s) so that it lasts a whole number of vibration periods. The
+// requested acceleration is returned if the result would fall below
+// MinimumAcceleration or if DAA is not in use.
+func (s *Shaper) DAAAcceleration(accel, speedChange float64) float64 {
+	if normalizeType(s.Type) != move.DAA || s.Frequency <= 0 || accel <= 0 || speedChange == 0 {
+		return accel
+	}
+	period := 1 / s.Frequency
+	n := math.Ceil(math.Abs(speedChange) / accel / period)
+	adjusted := math.Abs(speedChange) / (n * period)
+	if adjusted < s.MinimumAcceleration {
+		return accel
+	}
+	return adjusted
+}
+
+// GCode returns the M593 code that configures this shaper
+func (s *Shaper) GCode() string {
+	if normalizeType(s.Type) == move.MoveInputShapingTypeNone {
+		return `M593 P"none"`
+	}
+	code := fmt.Sprintf(`M593 P"%s" F%g`, strings.ToLower(string(s.Type)), s.Frequency)
+	if normalizeType(s.Type) != move.DAA {
+		code += fmt.Sprintf(" S%g", s.Damping)
+	} else if s.MinimumAcceleration > 0 {
+		code += fmt.Sprintf(" L%g", s.MinimumAcceleration)
+	}
+	return code
+}
+
+// Suggestion rates a shaper for a measured resonance
+type Suggestion struct {
+	// Shaper tuned to the resonance
+	Shaper *Shaper `json:"shaper"`
+	// Vibration is the highest residual vibration within the tolerance band
+	Vibration float64 `json:"vibration"`
+	// Duration of the shaper (in s)
+	Duration float64 `json:"duration"`
+	// MaxAcceleration that keeps the smoothing acceptable (in mm/s^2)
+	MaxAcceleration float64 `json:"maxAcceleration"`
+}
+
+// Suggest tunes every impulse shaper to the given resonance (in Hz) and damping
+// ratio and rates it by the highest residual vibration within +/- tolerance
+// (relative, e.g. 0.2) around the resonance. Suggestions are ordered from best
+// to worst: shapers that reduce the vibration below 5% come first ordered by
+// their maximum acceleration, the others follow ordered by vibration.
+func Suggest(resonance, damping, tolerance, maxSmoothing float64) ([]Suggestion, error) {
+	var result []Suggestion
+	for _, t := range []move.MoveInputShapingType{move.ZVD, move.ZVDD, move.EI2} {
+		s, err := New(t, resonance, damping)
+		if err != nil {
+			return nil, err
+		}
+		var worst float64
+		for _, p := range s.Response(resonance*(1-tolerance), resonance*(1+tolerance), resonance*tolerance/20, damping) {
+			worst = math.Max(worst, p.Vibration)
+		}
+		result = append(result, Suggestion{
+			Shaper:          s,
+			Vibration:       worst,
+			Duration:        s.Duration(),
+			MaxAcceleration: s.MaxAcceleration(maxSmoothing),
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		aOk, bOk := a.Vibration <= vibrationTolerance, b.Vibration <= vibrationTolerance
+		if aOk != bOk {
+			return aOk
+		}
+		if aOk {
+			return a.MaxAcceleration > b.MaxAcceleration
+		}
+		return a.Vibration < b.Vibration
+	})
+	return result, nil
+}
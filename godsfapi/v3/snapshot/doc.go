@@ -0,0 +1,10 @@
+/*
+Package snapshot maintains immutable object model snapshots for concurrent readers.
+
+A Store applies object model patches using copy-on-write: every patch produces a
+new snapshot that shares all top-level members that were not patched with the
+previous one, so only the patched members are copied. Readers may use a snapshot
+from any goroutine for as long as they like because it is never modified again.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package snapshot
@@ -0,0 +1,138 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package snapshot
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/extra"
+)
+
+// Store holds the latest snapshot of the object model.
+// Snapshots returned by a Store must not be modified.
+type Store struct {
+	mu       sync.Mutex
+	current  atomic.Value
+	sequence uint64
+}
+
+// Snapshot is an immutable state of the object model
+type Snapshot struct {
+	// Model is the object model which must be treated as read-only
+	Model *machine.MachineModel
+	// Sequence is incremented with every applied patch or replaced model
+	Sequence uint64
+}
+
+// NewStore creates a new Store starting with the given model. The store takes
+// ownership of m so it must not be modified afterwards.
+func NewStore(m *machine.MachineModel) *Store {
+	if m == nil {
+		m = machine.NewMachineModel()
+	}
+	s := &Store{}
+	s.current.Store(&Snapshot{Model: m})
+	return s
+}
+
+// Load returns the current snapshot. It never blocks and is safe to call from
+// any goroutine.
+func (s *Store) Load() *Snapshot {
+	return s.current.Load().(*Snapshot)
+}
+
+// Model returns the object model of the current snapshot
+func (s *Store) Model() *machine.MachineModel {
+	return s.Load().Model
+}
+
+// Replace publishes a full object model as new snapshot and takes ownership of it
+func (s *Store) Replace(m *machine.MachineModel) *Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.publish(m)
+}
+
+// Apply applies a (partial) object model update like MachineModel.UpdateFromJson
+// and publishes the result as new snapshot. Only the patched top-level members
+// are copied, all others are shared with the previous snapshot. If the patch
+// cannot be applied the current snapshot remains unchanged.
+func (s *Store) Apply(patch []byte) (*Snapshot, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := *s.Load().Model
+	v := reflect.ValueOf(&next).Elem()
+	for k := range members {
+		if i, ok := fieldIndex(k); ok {
+			v.Field(i).Set(reflect.ValueOf(machine.DeepCopy(v.Field(i).Interface())))
+		}
+	}
+	if next.Extra != nil {
+		// Unknown members are merged into the map in place
+		fields := make(extra.Fields, len(next.Extra))
+		for k, raw := range next.Extra {
+			fields[k] = raw
+		}
+		next.Extra = fields
+	}
+	if err := next.UpdateFromJson(patch); err != nil {
+		return nil, err
+	}
+	return s.publish(&next), nil
+}
+
+// Follow retrieves the full object model from a subscription in SubscriptionModePatch
+// and applies every received patch until an error occurs
+func (s *Store) Follow(sub connection.ModelSubscription) error {
+	m, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	s.Replace(m)
+	for {
+		patch, err := sub.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		if _, err = s.Apply([]byte(patch)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Store) publish(m *machine.MachineModel) *Snapshot {
+	s.sequence++
+	snap := &Snapshot{Model: m, Sequence: s.sequence}
+	s.current.Store(snap)
+	return snap
+}
+
+// fieldIndices maps the lower-case JSON names of the top-level members to their fields
+var fieldIndices = func() map[string]int {
+	t := reflect.TypeOf(machine.MachineModel{})
+	result := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			result[strings.ToLower(name)] = i
+		}
+	}
+	return result
+}()
+
+// fieldIndex returns the field of a top-level member matching it case-insensitively
+// like encoding/json
+func fieldIndex(key string) (int, bool) {
+	i, ok := fieldIndices[strings.ToLower(key)]
+	return i, ok
+}
@@ -0,0 +1,12 @@
+/*
+Package spindle controls the spindles of CNC machines.
+
+A Controller follows the spindles, tools, machine mode and general-purpose
+inputs of the object model. It starts and stops spindles using M3, M4 and M5
+after checking the requested speed against the spindle configuration and
+waits for spindles to reach their speed. With an Interlock configured, Enforce
+refuses cutting moves while the spindle of the current tool is too slow and
+refuses to start spindles while the door input reports an open door.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package spindle
@@ -0,0 +1,181 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package spindle
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/messages"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/spindles"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+)
+
+// Interlock configures the conditions a Controller enforces in CNC mode
+type Interlock struct {
+	// MinRpm is the lowest spindle speed for cutting moves or 0 to only require a running spindle
+	MinRpm float64
+	// DoorInput is the index of the general-purpose input of the door switch or -1 if there is none
+	DoorInput int
+	// DoorOpenBelow is set if the door input reads low (0) when the door is open
+	DoorOpenBelow bool
+}
+
+// NewInterlock creates an Interlock requiring the given spindle speed without a door switch
+func NewInterlock(minRpm float64) *Interlock {
+	return &Interlock{MinRpm: minRpm, DoorInput: -1}
+}
+
+// checkDoor returns ErrDoorOpen if the door input reports an open door. Must be called with mu held.
+func (c *Controller) checkDoor() error {
+	il := c.Interlock
+	if il == nil || il.DoorInput < 0 {
+		return nil
+	}
+	if il.DoorInput >= len(c.gpIn) || c.gpIn[il.DoorInput] == nil {
+		// A missing door switch must not be mistaken for a closed door
+		return fmt.Errorf("%w: input %d is not configured", ErrDoorOpen, il.DoorInput)
+	}
+	high := c.gpIn[il.DoorInput].Value >= 0.5
+	if high != il.DoorOpenBelow {
+		return ErrDoorOpen
+	}
+	return nil
+}
+
+// isCuttingMove returns true for G1, G2 and G3 with at least one coordinate
+func isCuttingMove(code *commands.Code) bool {
+	if code.Type != commands.GCode || !(code.IsMajorNumber(1) || code.IsMajorNumber(2) || code.IsMajorNumber(3)) {
+		return false
+	}
+	for _, p := range code.Parameters {
+		if p.Letter != "F" && p.Letter != "f" {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpindleStart returns true for M3 and M4 with a speed other than zero
+func isSpindleStart(code *commands.Code) bool {
+	if code.Type != commands.MCode || !(code.IsMajorNumber(3) || code.IsMajorNumber(4)) {
+		return false
+	}
+	s, err := code.ParameterOrDefault("S", 1.0).AsFloat64()
+	return err != nil || s != 0
+}
+
+// spindleCode returns the spindle and the speed (in RPM, negative in reverse) an
+// M3, M4 or M5 code selects. Must be called with mu held.
+func (c *Controller) spindleCode(code *commands.Code) (index int, rpm float64, ok bool) {
+	if code.Type != commands.MCode || code.MajorNumber == nil {
+		return 0, 0, false
+	}
+	switch *code.MajorNumber {
+	case 3, 4, 5:
+	default:
+		return 0, 0, false
+	}
+
+	index = c.toolSpindle(c.state.CurrentTool)
+	if p := code.Parameter("P"); p != nil {
+		n, err := p.AsInt64()
+		if err != nil {
+			return 0, 0, false
+		}
+		index = int(n)
+	} else if index < 0 {
+		index = 0
+	}
+	if *code.MajorNumber == 5 {
+		return index, 0, true
+	}
+	if code.Parameter("S") == nil {
+		// The previous speed is kept, which is only known to the firmware
+		return 0, 0, false
+	}
+	rpm, err := code.Parameter("S").AsFloat64()
+	if err != nil {
+		return 0, 0, false
+	}
+	if *code.MajorNumber == 4 {
+		rpm = -rpm
+	}
+	return index, rpm, true
+}
+
+// Check returns an error if the given code violates the Interlock.
+// Codes are only checked in CNC mode. Cutting moves are allowed if the current
+// tool has no spindle. Spindle codes that passed Enforce take precedence over
+// the object model until it reflects them, because codes are read ahead.
+func (c *Controller) Check(code *commands.Code) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	il := c.Interlock
+	if il == nil || c.state.MachineMode != state.CNC {
+		return nil
+	}
+	if isSpindleStart(code) {
+		return c.checkDoor()
+	}
+	if isCuttingMove(code) {
+		index := c.toolSpindle(c.state.CurrentTool)
+		if index < 0 || index >= len(c.spindles) {
+			return nil
+		}
+		if rpm, ok := c.commanded[index]; ok {
+			if rpm == 0 {
+				return fmt.Errorf("%w: spindle %d is stopped", ErrSpindleStopped, index)
+			}
+			if math.Abs(rpm) < il.MinRpm {
+				return fmt.Errorf("%w: spindle %d is set to %g RPM but %g RPM are required", ErrSpindleStopped, index, math.Abs(rpm), il.MinRpm)
+			}
+			return nil
+		}
+		s := &c.spindles[index]
+		if s.State != spindles.Forward && s.State != spindles.Reverse {
+			return fmt.Errorf("%w: spindle %d is %s", ErrSpindleStopped, index, s.State)
+		}
+		if rpm := math.Abs(s.Current); rpm < il.MinRpm {
+			return fmt.Errorf("%w: spindle %d runs at %g RPM but %g RPM are required", ErrSpindleStopped, index, rpm, il.MinRpm)
+		}
+	}
+	return nil
+}
+
+// record remembers the speed set by a spindle code that passed Check
+func (c *Controller) record(code *commands.Code) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if index, rpm, ok := c.spindleCode(code); ok {
+		c.commanded[index] = rpm
+	}
+}
+
+// CodeInterceptor receives codes and decides about them, e.g. a connection.InterceptConnection
+type CodeInterceptor interface {
+	ReceiveCode() (*commands.Code, error)
+	IgnoreCode() error
+	ResolveCode(mType messages.MessageType, content string) error
+}
+
+// Enforce resolves every code of the given interceptor that fails Check with an
+// error message and lets all other codes pass. The interceptor should be connected
+// in pre mode. Enforce returns when receiving or answering a code fails.
+func (c *Controller) Enforce(ic CodeInterceptor) error {
+	for {
+		code, err := ic.ReceiveCode()
+		if err != nil {
+			return err
+		}
+		if cerr := c.Check(code); cerr != nil {
+			err = ic.ResolveCode(messages.Error, cerr.Error())
+		} else {
+			c.record(code)
+			err = ic.IgnoreCode()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
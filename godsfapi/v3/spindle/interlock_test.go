@@ -0,0 +1,150 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package spindle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/commands"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/messages"
+)
+
+const model = `{"state":{"machineMode":"CNC","currentTool":0},"tools":[{"number":0,"spindle":0}],
+	"sensors":{"gpIn":[{"value":0}]},
+	"spindles":[{"state":"stopped","min":100,"max":20000}]}`
+
+// newCode creates a code with parameters given as letter-value pairs
+func newCode(t commands.CodeType, major int64, params ...interface{}) *commands.Code {
+	c := commands.NewCode()
+	c.Type = t
+	c.MajorNumber = &major
+	for i := 0; i+1 < len(params); i += 2 {
+		c.Parameters = append(c.Parameters, *commands.NewSimpleCodeParameter(params[i].(string), params[i+1]))
+	}
+	return c
+}
+
+func newController(t *testing.T, patches ...string) *Controller {
+	t.Helper()
+	mm := machine.NewMachineModel()
+	if err := mm.UpdateFromJson([]byte(model)); err != nil {
+		t.Fatalf("Failed to parse model: %v", err)
+	}
+	c := NewController(nil)
+	c.SetModel(mm)
+	for _, p := range patches {
+		if err := c.ApplyPatch([]byte(p)); err != nil {
+			t.Fatalf("ApplyPatch failed: %v", err)
+		}
+	}
+	return c
+}
+
+func TestCheck(t *testing.T) {
+	move := newCode(commands.GCode, 1, "X", 10.0)
+	door := &Interlock{MinRpm: 1000, DoorInput: 0}
+	tests := []struct {
+		name      string
+		patch     string
+		interlock *Interlock
+		code      *commands.Code
+		expected  error
+	}{
+		{"stopped spindle", "", NewInterlock(1000), move, ErrSpindleStopped},
+		{"feed rate only", "", NewInterlock(1000), newCode(commands.GCode, 1, "F", 600.0), nil},
+		{"rapid move", "", NewInterlock(1000), newCode(commands.GCode, 0, "Z", 5.0), nil},
+		{"below minimum speed", `{"spindles":[{"state":"forward","active":500,"current":500}]}`, NewInterlock(1000), move, ErrSpindleStopped},
+		{"running spindle", `{"spindles":[{"state":"forward","active":12000,"current":12000}]}`, NewInterlock(1000), move, nil},
+		{"reverse spindle", `{"spindles":[{"state":"reverse","active":12000,"current":-12000}]}`, NewInterlock(1000), move, nil},
+		{"tool without spindle", `{"tools":[{"spindle":-1}]}`, NewInterlock(1000), move, nil},
+		{"not in CNC mode", `{"state":{"machineMode":"FFF"}}`, NewInterlock(1000), move, nil},
+		{"no interlock", "", nil, move, nil},
+		{"closed door", "", door, newCode(commands.MCode, 3, "S", 12000.0), nil},
+		{"open door", `{"sensors":{"gpIn":[{"value":1}]}}`, door, newCode(commands.MCode, 3, "S", 12000.0), ErrDoorOpen},
+		{"open door with inverted input", "", &Interlock{DoorInput: 0, DoorOpenBelow: true}, newCode(commands.MCode, 4, "S", 12000.0), ErrDoorOpen},
+		{"missing door switch", "", &Interlock{DoorInput: 3}, newCode(commands.MCode, 3, "S", 12000.0), ErrDoorOpen},
+		{"stop with open door", `{"sensors":{"gpIn":[{"value":1}]}}`, door, newCode(commands.MCode, 5), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patches []string
+			if tt.patch != "" {
+				patches = append(patches, tt.patch)
+			}
+			c := newController(t, patches...)
+			c.Interlock = tt.interlock
+			if err := c.Check(tt.code); !errors.Is(err, tt.expected) {
+				t.Errorf("Check returned %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// fakeInterceptor delivers the given codes and records how they were answered
+type fakeInterceptor struct {
+	codes   []*commands.Code
+	results []bool
+}
+
+var errNoMoreCodes = errors.New("No more codes")
+
+func (f *fakeInterceptor) ReceiveCode() (*commands.Code, error) {
+	if len(f.codes) == 0 {
+		return nil, errNoMoreCodes
+	}
+	c := f.codes[0]
+	f.codes = f.codes[1:]
+	return c, nil
+}
+
+func (f *fakeInterceptor) IgnoreCode() error {
+	f.results = append(f.results, true)
+	return nil
+}
+
+func (f *fakeInterceptor) ResolveCode(mType messages.MessageType, content string) error {
+	f.results = append(f.results, false)
+	return nil
+}
+
+func TestEnforceReadAhead(t *testing.T) {
+	c := newController(t)
+	c.Interlock = NewInterlock(1000)
+	move := newCode(commands.GCode, 1, "X", 10.0)
+	ic := &fakeInterceptor{codes: []*commands.Code{
+		move,
+		newCode(commands.MCode, 3, "S", 12000.0),
+		move,
+		newCode(commands.MCode, 3, "S", 500.0),
+		move,
+		newCode(commands.MCode, 5),
+		move,
+	}}
+	if err := c.Enforce(ic); err != errNoMoreCodes {
+		t.Fatalf("Enforce returned %v", err)
+	}
+	expected := []bool{false, true, true, true, false, true, false}
+	for i := range expected {
+		if i >= len(ic.results) || ic.results[i] != expected[i] {
+			t.Fatalf("Expected %v but got %v", expected, ic.results)
+		}
+	}
+
+	// The object model still reports the spindle running from the first M3
+	if err := c.ApplyPatch([]byte(`{"spindles":[{"state":"forward","active":12000,"current":12000}]}`)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if err := c.Check(move); !errors.Is(err, ErrSpindleStopped) {
+		t.Errorf("Check returned %v before M5 was reflected by the model", err)
+	}
+	if err := c.ApplyPatch([]byte(`{"spindles":[{"state":"stopped","active":0,"current":0}]}`)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if err := c.ApplyPatch([]byte(`{"spindles":[{"state":"forward","active":8000,"current":8000}]}`)); err != nil {
+		t.Fatalf("ApplyPatch failed: %v", err)
+	}
+	if err := c.Check(move); err != nil {
+		t.Errorf("Check returned %v after the model caught up", err)
+	}
+}
@@ -0,0 +1,291 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package spindle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/sensors"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/spindles"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+// DefaultTolerance is the default relative deviation at which a spindle is considered up to speed
+const DefaultTolerance = 0.05
+
+var (
+	// ErrNotCNC is returned if the machine is not in CNC mode
+	ErrNotCNC = errors.New("Machine is not in CNC mode")
+	// ErrUnknownSpindle is returned if a spindle does not exist or is not configured
+	ErrUnknownSpindle = errors.New("Spindle is not configured")
+	// ErrInvalidSpeed is returned if a speed is outside the range of a spindle
+	ErrInvalidSpeed = errors.New("Invalid spindle speed")
+	// ErrDoorOpen is returned if a spindle must not start because the door is open
+	ErrDoorOpen = errors.New("Door is open")
+	// ErrSpindleStopped is returned if a cutting move is refused because the spindle is too slow
+	ErrSpindleStopped = errors.New("Spindle is not running")
+)
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// toolSpindle is the part of a tool that is needed to find its spindle
+type toolSpindle struct {
+	Number  int64 `json:"number"`
+	Spindle int64 `json:"spindle"`
+}
+
+// machineState is the part of the machine state the Controller depends on
+type machineState struct {
+	CurrentTool int64             `json:"currentTool"`
+	MachineMode state.MachineMode `json:"machineMode"`
+}
+
+// Controller starts and stops spindles and enforces an Interlock
+type Controller struct {
+	// Channel to send codes to
+	Channel types.CodeChannel
+	// Interlock to enforce or nil to allow everything
+	Interlock *Interlock
+
+	performer CodePerformer
+	mu        sync.Mutex
+	spindles  []spindles.Spindle
+	tools     []toolSpindle
+	gpIn      []*sensors.GpInputPort
+	state     machineState
+	changed   chan struct{}
+	// commanded holds the speeds (in RPM, negative in reverse) of spindle codes that
+	// passed Enforce but are not reflected by the object model yet
+	commanded map[int]float64
+}
+
+// NewController creates a new Controller sending codes to the given performer
+func NewController(p CodePerformer) *Controller {
+	return &Controller{
+		Channel:   types.DefaultChannel,
+		performer: p,
+		state:     machineState{CurrentTool: -1, MachineMode: state.FFF},
+		changed:   make(chan struct{}),
+		commanded: make(map[int]float64),
+	}
+}
+
+// notify wakes up everybody waiting for a change. Must be called with mu held.
+func (c *Controller) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// SetModel takes the spindles, tools, inputs and state from a full object model
+func (c *Controller) SetModel(mm *machine.MachineModel) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spindles = append([]spindles.Spindle(nil), mm.Spindles...)
+	c.tools = make([]toolSpindle, len(mm.Tools))
+	for i, t := range mm.Tools {
+		c.tools[i] = toolSpindle{Number: t.Number, Spindle: t.Spindle}
+	}
+	c.gpIn = make([]*sensors.GpInputPort, len(mm.Sensors.GpIn))
+	for i, p := range mm.Sensors.GpIn {
+		if p != nil {
+			cp := *p
+			c.gpIn[i] = &cp
+		}
+	}
+	c.state = machineState{CurrentTool: mm.State.CurrentTool, MachineMode: mm.State.MachineMode}
+	c.settleCommanded()
+	c.notify()
+}
+
+// ApplyPatch applies a patch of a SubscribeConnection
+func (c *Controller) ApplyPatch(patch []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	type inputs struct {
+		GpIn *[]*sensors.GpInputPort `json:"gpIn"`
+	}
+	p := struct {
+		Spindles *[]spindles.Spindle `json:"spindles"`
+		Tools    *[]toolSpindle      `json:"tools"`
+		Sensors  inputs              `json:"sensors"`
+		State    *machineState       `json:"state"`
+	}{Spindles: &c.spindles, Tools: &c.tools, Sensors: inputs{GpIn: &c.gpIn}, State: &c.state}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return err
+	}
+	c.settleCommanded()
+	c.notify()
+	return nil
+}
+
+// settleCommanded forgets commanded speeds the object model has caught up with.
+// Must be called with mu held.
+func (c *Controller) settleCommanded() {
+	for index, rpm := range c.commanded {
+		if index >= len(c.spindles) {
+			delete(c.commanded, index)
+			continue
+		}
+		s := &c.spindles[index]
+		switch {
+		case rpm == 0 && s.State != spindles.Forward && s.State != spindles.Reverse,
+			rpm > 0 && s.State == spindles.Forward && s.Active == rpm,
+			rpm < 0 && s.State == spindles.Reverse && s.Active == -rpm:
+			delete(c.commanded, index)
+		}
+	}
+}
+
+// Watch keeps the Controller up to date until the subscription fails
+func (c *Controller) Watch(sub connection.ModelSubscription) error {
+	mm, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	c.SetModel(mm)
+
+	for {
+		patch, err := sub.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		if err = c.ApplyPatch([]byte(patch)); err != nil {
+			return err
+		}
+	}
+}
+
+// Spindle returns a copy of the spindle with the given index
+func (c *Controller) Spindle(index int) (spindles.Spindle, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if index < 0 || index >= len(c.spindles) {
+		return spindles.Spindle{}, false
+	}
+	return c.spindles[index], true
+}
+
+// ToolSpindle returns the index of the spindle mapped to the given tool or -1 if there is none
+func (c *Controller) ToolSpindle(number int64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.toolSpindle(number)
+}
+
+// toolSpindle returns the spindle of a tool. Must be called with mu held.
+func (c *Controller) toolSpindle(number int64) int {
+	for _, t := range c.tools {
+		if t.Number == number {
+			return int(t.Spindle)
+		}
+	}
+	return -1
+}
+
+// configured returns the given spindle if it is configured. Must be called with mu held.
+func (c *Controller) configured(index int) (*spindles.Spindle, error) {
+	if index < 0 || index >= len(c.spindles) || c.spindles[index].State == spindles.Unconfigured {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSpindle, index)
+	}
+	return &c.spindles[index], nil
+}
+
+// SetSpeed starts a spindle or changes its speed (in RPM) and direction
+func (c *Controller) SetSpeed(index int, rpm float64, direction spindles.SpindleState) error {
+	c.mu.Lock()
+	code, err := c.speedCode(index, rpm, direction)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.perform(code)
+}
+
+// speedCode validates a speed request. Must be called with mu held.
+func (c *Controller) speedCode(index int, rpm float64, direction spindles.SpindleState) (string, error) {
+	if c.state.MachineMode != state.CNC {
+		return "", ErrNotCNC
+	}
+	s, err := c.configured(index)
+	if err != nil {
+		return "", err
+	}
+	if rpm <= 0 || (s.Min > 0 && rpm < s.Min) || (s.Max > 0 && rpm > s.Max) {
+		return "", fmt.Errorf("%w: %g RPM is outside %g..%g RPM", ErrInvalidSpeed, rpm, s.Min, s.Max)
+	}
+	if err = c.checkDoor(); err != nil {
+		return "", err
+	}
+	switch direction {
+	case spindles.Forward:
+		return fmt.Sprintf("M3 P%d S%g", index, rpm), nil
+	case spindles.Reverse:
+		return fmt.Sprintf("M4 P%d S%g", index, rpm), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported direction %s", ErrInvalidSpeed, direction)
+	}
+}
+
+// Stop stops a spindle
+func (c *Controller) Stop(index int) error {
+	c.mu.Lock()
+	_, err := c.configured(index)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.perform(fmt.Sprintf("M5 P%d", index))
+}
+
+// WaitForSpeed waits until the current speed of a spindle is within the given
+// relative tolerance of rpm or until ctx is done. Pass 0 to wait for a stopped
+// spindle to run down and a tolerance of 0 to use DefaultTolerance.
+func (c *Controller) WaitForSpeed(ctx context.Context, index int, rpm, tolerance float64) error {
+	target := math.Abs(rpm)
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	for {
+		c.mu.Lock()
+		s, err := c.configured(index)
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		reached := math.Abs(math.Abs(s.Current)-target) <= math.Max(target*tolerance, 1)
+		changed := c.changed
+		c.mu.Unlock()
+		if reached {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// perform runs a code and turns error replies into errors
+func (c *Controller) perform(code string) error {
+	reply, err := c.performer.PerformSimpleCode(code, c.Channel)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(reply, "Error") {
+		return fmt.Errorf("%s: %s", code, strings.TrimSpace(reply))
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+/*
+Package tools selects and configures tools and reports tool changes.
+
+A Manager follows the tools, heaters and axes of the object model and builds
+the T, G10, M568, M567 and M207 codes to select tools and change their
+temperatures, offsets, mix ratios and retraction settings. Every request is
+validated against the model before it is sent. Watch derives the phases of
+tool changes (tfree, tpre and tpost) from the changes of the machine state.
+*/
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package tools
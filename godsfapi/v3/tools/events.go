@@ -0,0 +1,101 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package tools
+
+import (
+	"fmt"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+)
+
+// Phase is a step of a tool change
+type Phase int
+
+const (
+	// Free while the old tool is being freed (tfree#.g)
+	Free Phase = iota
+	// Pre while the new tool is being prepared (tpre#.g)
+	Pre
+	// Post after the new tool has been selected (tpost#.g)
+	Post
+	// Done when the tool change has finished
+	Done
+)
+
+// String returns the name of this phase like the macro it corresponds to
+func (p Phase) String() string {
+	switch p {
+	case Free:
+		return "tfree"
+	case Pre:
+		return "tpre"
+	case Post:
+		return "tpost"
+	case Done:
+		return "done"
+	default:
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+}
+
+// Event reports that a tool change entered a phase
+type Event struct {
+	// Phase the tool change entered
+	Phase Phase
+	// From is the number of the old tool or -1 if none was selected
+	From int64
+	// To is the number of the new tool or -1 if the tool is only deselected
+	To int64
+}
+
+// String returns a description of this event
+func (e Event) String() string {
+	return fmt.Sprintf("T%d -> T%d: %s", e.From, e.To, e.Phase)
+}
+
+// changeTracker derives tool change events from state changes
+type changeTracker struct {
+	changing bool
+	phase    Phase
+	from, to int64
+	current  int64
+}
+
+func (c *changeTracker) reset(current int64) {
+	*c = changeTracker{current: current, from: -1, to: -1}
+}
+
+// update returns the events caused by the given state
+func (c *changeTracker) update(s *toolState) []Event {
+	var events []Event
+	if s.Status == state.ChangingTool {
+		if !c.changing {
+			c.changing, c.from, c.to, c.phase = true, c.current, s.NextTool, -1
+		}
+		c.to = s.NextTool
+
+		// RepRapFirmware frees the old tool, deselects it and selects the new
+		// tool before running tpost
+		phase := c.phase
+		switch {
+		case s.CurrentTool >= 0 && s.CurrentTool == c.from && c.from != c.to:
+			phase = Free
+		case s.CurrentTool < 0 && c.to >= 0:
+			phase = Pre
+		case s.CurrentTool >= 0 && s.CurrentTool == c.to:
+			phase = Post
+		}
+		if phase > c.phase {
+			// Skipped phases are not reported because their macros did not run
+			c.phase = phase
+			events = append(events, Event{Phase: phase, From: c.from, To: c.to})
+		}
+	} else if c.changing {
+		events = append(events, Event{Phase: Done, From: c.from, To: s.CurrentTool})
+		c.changing = false
+	} else if s.CurrentTool != c.current {
+		// Tool changes without macros are too quick to show up as changingTool
+		events = append(events, Event{Phase: Done, From: c.current, To: s.CurrentTool})
+	}
+	c.current = s.CurrentTool
+	return events
+}
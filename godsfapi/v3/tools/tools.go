@@ -0,0 +1,359 @@
+// Deprecated: This package was deprected, please visit https://github.com/Duet3D/dsf-go.
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/connection"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/heat"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/state"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/machine/tool"
+	"github.com/Duet3D/DSF-APIs/godsfapi/v3/types"
+)
+
+var (
+	// ErrUnknownTool is returned if a tool does not exist
+	ErrUnknownTool = errors.New("Tool does not exist")
+	// ErrInvalidValue is returned if a value cannot be applied to a tool
+	ErrInvalidValue = errors.New("Invalid value")
+)
+
+// Macros is a bit mask of the tool change macros to run
+type Macros int
+
+const (
+	// TFree runs tfree#.g of the old tool
+	TFree Macros = 1 << iota
+	// TPre runs tpre#.g of the new tool
+	TPre
+	// TPost runs tpost#.g of the new tool
+	TPost
+	// NoMacros runs no tool change macros
+	NoMacros = 0
+	// AllMacros runs all tool change macros
+	AllMacros = TFree | TPre | TPost
+)
+
+// CodePerformer runs codes, e.g. a connection.CommandConnection
+type CodePerformer interface {
+	PerformSimpleCode(code string, channel types.CodeChannel) (string, error)
+}
+
+// axis is the part of an axis that is needed to set offsets
+type axis struct {
+	Letter string `json:"letter"`
+}
+
+// toolState is the part of the machine state that is needed to follow tool changes
+type toolState struct {
+	CurrentTool  int64               `json:"currentTool"`
+	NextTool     int64               `json:"nextTool"`
+	PreviousTool int64               `json:"previousTool"`
+	Status       state.MachineStatus `json:"status"`
+}
+
+// Manager validates and sends tool codes and follows tool changes
+type Manager struct {
+	// Channel to send codes to
+	Channel types.CodeChannel
+
+	performer CodePerformer
+	mu        sync.Mutex
+	tools     []tool.Tool
+	heaters   []heat.Heater
+	axes      []axis
+	state     toolState
+	changes   changeTracker
+}
+
+// NewManager creates a new Manager sending codes to the given performer
+func NewManager(p CodePerformer) *Manager {
+	m := &Manager{Channel: types.DefaultChannel, performer: p}
+	m.state.CurrentTool, m.state.NextTool, m.state.PreviousTool = -1, -1, -1
+	m.changes.reset(-1)
+	return m
+}
+
+// SetModel takes the tools, heaters, axes and tool state from a full object model
+func (m *Manager) SetModel(mm *machine.MachineModel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tools = append([]tool.Tool(nil), mm.Tools...)
+	m.heaters = append([]heat.Heater(nil), mm.Heat.Heaters...)
+	m.axes = make([]axis, len(mm.Move.Axes))
+	for i, a := range mm.Move.Axes {
+		m.axes[i].Letter = a.Letter
+	}
+	m.state = toolState{
+		CurrentTool:  mm.State.CurrentTool,
+		NextTool:     mm.State.NextTool,
+		PreviousTool: mm.State.PreviousTool,
+		Status:       mm.State.Status,
+	}
+	m.changes.reset(mm.State.CurrentTool)
+}
+
+// ApplyPatch applies a patch of a SubscribeConnection and returns the tool
+// change events it caused
+func (m *Manager) ApplyPatch(patch []byte) ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	type heaters struct {
+		Heaters *[]heat.Heater `json:"heaters"`
+	}
+	type axes struct {
+		Axes *[]axis `json:"axes"`
+	}
+	p := struct {
+		Tools *[]tool.Tool `json:"tools"`
+		Heat  heaters      `json:"heat"`
+		Move  axes         `json:"move"`
+		State *toolState   `json:"state"`
+	}{Tools: &m.tools, Heat: heaters{Heaters: &m.heaters}, Move: axes{Axes: &m.axes}, State: &m.state}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return m.changes.update(&m.state), nil
+}
+
+// Watch follows the object model and calls f for every tool change event
+// until the subscription fails
+func (m *Manager) Watch(sub connection.ModelSubscription, f func(Event)) error {
+	mm, err := sub.GetMachineModel()
+	if err != nil {
+		return err
+	}
+	m.SetModel(mm)
+
+	for {
+		patch, err := sub.GetMachineModelPatch()
+		if err != nil {
+			return err
+		}
+		events, err := m.ApplyPatch([]byte(patch))
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			f(e)
+		}
+	}
+}
+
+// Tool returns a copy of the tool with the given number
+func (m *Manager) Tool(number int64) (tool.Tool, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := m.find(number)
+	if t == nil {
+		return tool.Tool{}, false
+	}
+	return *t, true
+}
+
+// Current returns the number of the selected tool or -1 if none is selected
+func (m *Manager) Current() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.CurrentTool
+}
+
+// find returns the tool with the given number. Must be called with mu held.
+func (m *Manager) find(number int64) *tool.Tool {
+	for i := range m.tools {
+		if m.tools[i].Number == number {
+			return &m.tools[i]
+		}
+	}
+	return nil
+}
+
+// build validates a request using the given function and performs the resulting code
+func (m *Manager) build(number int64, f func(t *tool.Tool) (string, error)) error {
+	m.mu.Lock()
+	t := m.find(number)
+	if t == nil {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %d", ErrUnknownTool, number)
+	}
+	code, err := f(t)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return m.perform(code)
+}
+
+// perform runs a code and turns error replies into errors
+func (m *Manager) perform(code string) error {
+	reply, err := m.performer.PerformSimpleCode(code, m.Channel)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(reply, "Error") {
+		return fmt.Errorf("%s: %s", code, strings.TrimSpace(reply))
+	}
+	return nil
+}
+
+// Select selects the tool with the given number running the given macros
+func (m *Manager) Select(number int64, macros Macros) error {
+	return m.build(number, func(t *tool.Tool) (string, error) {
+		return fmt.Sprintf("T%d P%d", number, macros), nil
+	})
+}
+
+// Deselect deselects the current tool running the given macros
+func (m *Manager) Deselect(macros Macros) error {
+	return m.perform(fmt.Sprintf("T-1 P%d", macros))
+}
+
+// SetTemperatures sets the active and standby temperatures of the heaters of a
+// tool in the order of Tool.Heaters. Pass nil to keep the temperatures of a kind.
+func (m *Manager) SetTemperatures(number int64, active, standby []float64) error {
+	return m.build(number, func(t *tool.Tool) (string, error) {
+		if active == nil && standby == nil {
+			return "", fmt.Errorf("%w: no temperatures given", ErrInvalidValue)
+		}
+		code := fmt.Sprintf("M568 P%d", number)
+		for _, set := range []struct {
+			letter string
+			values []float64
+		}{{"S", active}, {"R", standby}} {
+			if set.values == nil {
+				continue
+			}
+			if err := m.checkTemperatures(t, set.values); err != nil {
+				return "", err
+			}
+			code += " " + set.letter + joinFloats(set.values)
+		}
+		return code, nil
+	})
+}
+
+// SetHeaterTemperature sets the temperatures of a single heater of a tool.
+// heater is the index in Tool.Heaters.
+func (m *Manager) SetHeaterTemperature(number int64, heater int, active, standby float64) error {
+	m.mu.Lock()
+	t := m.find(number)
+	if t == nil {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %d", ErrUnknownTool, number)
+	}
+	if heater < 0 || heater >= len(t.Heaters) {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: tool %d has no heater %d", ErrInvalidValue, number, heater)
+	}
+	a := withValue(t.Active, len(t.Heaters), heater, active)
+	s := withValue(t.Standby, len(t.Heaters), heater, standby)
+	m.mu.Unlock()
+	return m.SetTemperatures(number, a, s)
+}
+
+// withValue returns a copy of values with n items where index i is replaced
+func withValue(values []float64, n, i int, v float64) []float64 {
+	result := make([]float64, n)
+	copy(result, values)
+	result[i] = v
+	return result
+}
+
+// checkTemperatures validates temperatures against the heaters of a tool
+func (m *Manager) checkTemperatures(t *tool.Tool, values []float64) error {
+	if len(values) > len(t.Heaters) {
+		return fmt.Errorf("%w: tool %d has %d heaters but %d temperatures are given", ErrInvalidValue, t.Number, len(t.Heaters), len(values))
+	}
+	for i, v := range values {
+		h := t.Heaters[i]
+		if h < 0 || int(h) >= len(m.heaters) {
+			return fmt.Errorf("%w: heater %d of tool %d does not exist", ErrInvalidValue, h, t.Number)
+		}
+		if v < heat.AbsoluteZero {
+			return fmt.Errorf("%w: %g C is below absolute zero", ErrInvalidValue, v)
+		}
+		if heater := &m.heaters[h]; heater.Max > 0 && v > heater.Max {
+			return fmt.Errorf("%w: %g C exceeds the maximum of heater %d (%g C)", ErrInvalidValue, v, h, heater.Max)
+		}
+	}
+	return nil
+}
+
+// SetOffsets sets the offsets of a tool by axis letter
+func (m *Manager) SetOffsets(number int64, offsets map[string]float64) error {
+	return m.build(number, func(t *tool.Tool) (string, error) {
+		if len(offsets) == 0 {
+			return "", fmt.Errorf("%w: no offsets given", ErrInvalidValue)
+		}
+		letters := make([]string, 0, len(offsets))
+		for l := range offsets {
+			letters = append(letters, l)
+		}
+		sort.Slice(letters, func(i, j int) bool { return strings.ToUpper(letters[i]) < strings.ToUpper(letters[j]) })
+		code := fmt.Sprintf("G10 P%d", number)
+		for _, l := range letters {
+			if !m.hasAxis(l) {
+				return "", fmt.Errorf("%w: axis %s does not exist", ErrInvalidValue, l)
+			}
+			code += fmt.Sprintf(" %s%.3f", strings.ToUpper(l), offsets[l])
+		}
+		return code, nil
+	})
+}
+
+// hasAxis returns true if an axis with the given letter exists. Must be called with mu held.
+func (m *Manager) hasAxis(letter string) bool {
+	for _, a := range m.axes {
+		if strings.EqualFold(a.Letter, letter) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMix sets the mix ratios of the extruders of a tool
+func (m *Manager) SetMix(number int64, mix []float64) error {
+	return m.build(number, func(t *tool.Tool) (string, error) {
+		if len(mix) == 0 || len(mix) != len(t.Extruders) {
+			return "", fmt.Errorf("%w: tool %d has %d extruders but %d mix ratios are given", ErrInvalidValue, number, len(t.Extruders), len(mix))
+		}
+		for _, v := range mix {
+			if v < 0 {
+				return "", fmt.Errorf("%w: negative mix ratio %g", ErrInvalidValue, v)
+			}
+		}
+		return fmt.Sprintf("M567 P%d E%s", number, joinFloats(mix)), nil
+	})
+}
+
+// SetRetraction configures firmware retraction of a tool
+func (m *Manager) SetRetraction(number int64, r tool.ToolRetraction) error {
+	return m.build(number, func(t *tool.Tool) (string, error) {
+		if len(t.Extruders) == 0 {
+			return "", fmt.Errorf("%w: tool %d has no extruders", ErrInvalidValue, number)
+		}
+		if r.Length < 0 || r.Speed <= 0 || r.UnretractSpeed < 0 || r.ZHop < 0 {
+			return "", fmt.Errorf("%w: retraction length, speeds and Z hop must not be negative and speed must be set", ErrInvalidValue)
+		}
+		unretract := r.UnretractSpeed
+		if unretract == 0 {
+			unretract = r.Speed
+		}
+		return fmt.Sprintf("M207 P%d S%g R%g F%g T%g Z%g", number, r.Length, r.ExtraRestart, r.Speed*60, unretract*60, r.ZHop), nil
+	})
+}
+
+// joinFloats formats a list of values like G-code parameters expect them
+func joinFloats(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%g", v)
+	}
+	return strings.Join(parts, ":")
+}